@@ -0,0 +1,101 @@
+package lightclient
+
+import (
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+// RankedUpdate is satisfied by both the full periodic LightClientUpdate stored in
+// BeaconDB.LightClientUpdates and the lighter LightClientFinalityUpdate gossiped over
+// p2p: both carry everything IsBetterUpdate needs, so the same comparator ranks full
+// updates against what's persisted for their period and finality updates against the
+// cached latest finality update.
+type RankedUpdate interface {
+	AttestedHeader() interfaces.LightClientHeader
+	FinalizedHeader() interfaces.LightClientHeader
+	SyncAggregate() *ethpb.SyncAggregate
+	SignatureSlot() primitives.Slot
+}
+
+// SyncCommitteePeriodAtSlot returns the sync committee period a given slot falls in. It is
+// exported so callers outside this package (e.g. the rpc and p2p light client code that
+// needs to key a stored or gossiped update by period) don't have to reimplement it.
+func SyncCommitteePeriodAtSlot(slot uint64) uint64 {
+	cfg := params.BeaconConfig()
+	epoch := slot / uint64(cfg.SlotsPerEpoch)
+	return epoch / uint64(cfg.EpochsPerSyncCommitteePeriod)
+}
+
+// hasSyncCommitteeFinality reports whether an update's finalized header falls in the same
+// sync committee period as its attested header, the first tiebreaker in is_better_update.
+func hasSyncCommitteeFinality(update RankedUpdate) bool {
+	finalized := update.FinalizedHeader()
+	if finalized == nil {
+		return false
+	}
+	return SyncCommitteePeriodAtSlot(uint64(update.AttestedHeader().Beacon().Slot)) ==
+		SyncCommitteePeriodAtSlot(uint64(finalized.Beacon().Slot))
+}
+
+// hasFinalizedHeader reports whether the update carries a non-empty finalized header,
+// i.e. it proves finality rather than just sync-committee participation.
+func hasFinalizedHeader(update RankedUpdate) bool {
+	finalized := update.FinalizedHeader()
+	return finalized != nil && finalized.Beacon().Slot != 0
+}
+
+// supermajorityParticipants clamps a participant count at the supermajority (2/3)
+// threshold, per step (2) of is_better_update: once an update clears the supermajority
+// bar, additional participants beyond it don't make it meaningfully "more final".
+func supermajorityParticipants(count uint64) uint64 {
+	threshold := (uint64(params.BeaconConfig().SyncCommitteeSize) * 2) / 3
+	if count > threshold {
+		return threshold
+	}
+	return count
+}
+
+// IsBetterUpdate reports whether newUpdate should replace oldUpdate as the best known
+// update for their shared sync committee period. It implements the spec's
+// is_better_update comparator, applied lexicographically:
+//
+//  1. has_sync_committee_finality (finalized header's period == attested header's period)
+//  2. sync_committee_bits.count(), clamped to the supermajority threshold
+//  3. whether the finalized header is non-empty
+//  4. the raw (unclamped) sync_committee_bits.count()
+//  5. older signature_slot wins (smaller is better)
+//  6. older attested_slot wins (smaller is better)
+func IsBetterUpdate(newUpdate, oldUpdate RankedUpdate) bool {
+	newParticipants := newUpdate.SyncAggregate().SyncCommitteeBits.Count()
+	oldParticipants := oldUpdate.SyncAggregate().SyncCommitteeBits.Count()
+
+	newFinality := hasSyncCommitteeFinality(newUpdate)
+	oldFinality := hasSyncCommitteeFinality(oldUpdate)
+	if newFinality != oldFinality {
+		return newFinality
+	}
+
+	newClamped := supermajorityParticipants(newParticipants)
+	oldClamped := supermajorityParticipants(oldParticipants)
+	if newClamped != oldClamped {
+		return newClamped > oldClamped
+	}
+
+	newHasFinalized := hasFinalizedHeader(newUpdate)
+	oldHasFinalized := hasFinalizedHeader(oldUpdate)
+	if newHasFinalized != oldHasFinalized {
+		return newHasFinalized
+	}
+
+	if newParticipants != oldParticipants {
+		return newParticipants > oldParticipants
+	}
+
+	if newUpdate.SignatureSlot() != oldUpdate.SignatureSlot() {
+		return newUpdate.SignatureSlot() < oldUpdate.SignatureSlot()
+	}
+
+	return newUpdate.AttestedHeader().Beacon().Slot < oldUpdate.AttestedHeader().Beacon().Slot
+}
@@ -0,0 +1,145 @@
+package lightclient
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+// fakeLightClientHeader is the minimal interfaces.LightClientHeader implementation
+// IsBetterUpdate's tests need: only Beacon().Slot is ever read by the comparator.
+type fakeLightClientHeader struct {
+	interfaces.LightClientHeader
+	slot primitives.Slot
+}
+
+func (f *fakeLightClientHeader) Beacon() *ethpb.BeaconBlockHeader {
+	return &ethpb.BeaconBlockHeader{Slot: f.slot}
+}
+
+// fakeRankedUpdate implements RankedUpdate with whatever combination of attested/
+// finalized slots, participant count and signature slot a test case needs.
+type fakeRankedUpdate struct {
+	attestedSlot  primitives.Slot
+	finalizedSlot primitives.Slot
+	hasFinalized  bool
+	participants  uint64
+	signatureSlot primitives.Slot
+}
+
+func (f *fakeRankedUpdate) AttestedHeader() interfaces.LightClientHeader {
+	return &fakeLightClientHeader{slot: f.attestedSlot}
+}
+
+func (f *fakeRankedUpdate) FinalizedHeader() interfaces.LightClientHeader {
+	if !f.hasFinalized {
+		return nil
+	}
+	return &fakeLightClientHeader{slot: f.finalizedSlot}
+}
+
+func (f *fakeRankedUpdate) SyncAggregate() *ethpb.SyncAggregate {
+	bits := bitfield.NewBitvector512()
+	for i := uint64(0); i < f.participants; i++ {
+		bits.SetBitAt(i, true)
+	}
+	return &ethpb.SyncAggregate{SyncCommitteeBits: bits}
+}
+
+func (f *fakeRankedUpdate) SignatureSlot() primitives.Slot {
+	return f.signatureSlot
+}
+
+func TestSupermajorityParticipants(t *testing.T) {
+	threshold := (uint64(512) * 2) / 3 // SyncCommitteeSize is 512 in the default (mainnet) config
+
+	tests := []struct {
+		name  string
+		count uint64
+		want  uint64
+	}{
+		{"below threshold", threshold - 10, threshold - 10},
+		{"exactly at threshold", threshold, threshold},
+		{"above threshold clamps", threshold + 50, threshold},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := supermajorityParticipants(tt.count); got != tt.want {
+				t.Errorf("supermajorityParticipants(%d) = %d, want %d", tt.count, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsBetterUpdate(t *testing.T) {
+	base := fakeRankedUpdate{
+		attestedSlot:  100,
+		finalizedSlot: 100,
+		hasFinalized:  true,
+		participants:  400,
+		signatureSlot: 101,
+	}
+
+	tests := []struct {
+		name        string
+		newUpdate   fakeRankedUpdate
+		oldUpdate   fakeRankedUpdate
+		wantNewWins bool
+	}{
+		{
+			name:        "sync committee finality beats no finality",
+			newUpdate:   base,
+			oldUpdate:   fakeRankedUpdate{attestedSlot: 100, finalizedSlot: 0, hasFinalized: false, participants: 400, signatureSlot: 101},
+			wantNewWins: true,
+		},
+		{
+			name:        "higher clamped participants wins",
+			newUpdate:   base,
+			oldUpdate:   fakeRankedUpdate{attestedSlot: 100, finalizedSlot: 100, hasFinalized: true, participants: 300, signatureSlot: 101},
+			wantNewWins: true,
+		},
+		{
+			// attested slot 9000 is in period 1 and finalized slot 100 in period 0, so neither
+			// update has sync committee finality and the comparison reaches the header check.
+			name:        "having a finalized header beats not having one, at equal clamped participants above threshold",
+			newUpdate:   fakeRankedUpdate{attestedSlot: 9000, finalizedSlot: 100, hasFinalized: true, participants: 500, signatureSlot: 9001},
+			oldUpdate:   fakeRankedUpdate{attestedSlot: 50, finalizedSlot: 0, hasFinalized: false, participants: 500, signatureSlot: 101},
+			wantNewWins: true,
+		},
+		{
+			name:        "higher raw participants wins the tiebreak",
+			newUpdate:   fakeRankedUpdate{attestedSlot: 100, finalizedSlot: 100, hasFinalized: true, participants: 450, signatureSlot: 101},
+			oldUpdate:   base,
+			wantNewWins: true,
+		},
+		{
+			name:        "older signature slot wins when everything else ties",
+			newUpdate:   fakeRankedUpdate{attestedSlot: 100, finalizedSlot: 100, hasFinalized: true, participants: 400, signatureSlot: 90},
+			oldUpdate:   base,
+			wantNewWins: true,
+		},
+		{
+			name:        "older attested slot wins as the final tiebreak",
+			newUpdate:   fakeRankedUpdate{attestedSlot: 80, finalizedSlot: 80, hasFinalized: true, participants: 400, signatureSlot: 101},
+			oldUpdate:   base,
+			wantNewWins: true,
+		},
+		{
+			name:        "identical updates: new does not win",
+			newUpdate:   base,
+			oldUpdate:   base,
+			wantNewWins: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsBetterUpdate(&tt.newUpdate, &tt.oldUpdate); got != tt.wantNewWins {
+				t.Errorf("IsBetterUpdate() = %v, want %v", got, tt.wantNewWins)
+			}
+		})
+	}
+}
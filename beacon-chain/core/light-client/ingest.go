@@ -0,0 +1,42 @@
+package lightclient
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+)
+
+// UpdateStore is the subset of BeaconDB's light client update storage IngestUpdate needs.
+// It is defined here, rather than depending on beacon-chain/db directly, so this package
+// stays usable from gossip and req-resp code paths that only have a narrower handle on
+// storage.
+type UpdateStore interface {
+	LightClientUpdates(ctx context.Context, startPeriod, endPeriod uint64) (map[uint64]interfaces.LightClientUpdate, error)
+	SaveLightClientUpdate(ctx context.Context, period uint64, update interfaces.LightClientUpdate) error
+}
+
+// IngestUpdate stores update as the best known update for its sync committee period,
+// replacing whatever is currently stored only if IsBetterUpdate says so, so the stored
+// update for a period is only ever replaced by a strictly better one. Its caller is the rpc
+// light client Server's SaveUpdate, which passes it the full updates derived locally from
+// suitableBlock; gossiped finality/optimistic updates are not full updates and never reach
+// it.
+//
+// The returned bool reports whether update was actually stored.
+func IngestUpdate(ctx context.Context, store UpdateStore, update interfaces.LightClientUpdate) (bool, error) {
+	period := SyncCommitteePeriodAtSlot(uint64(update.AttestedHeader().Beacon().Slot))
+
+	existing, err := store.LightClientUpdates(ctx, period, period)
+	if err != nil {
+		return false, err
+	}
+
+	if current, ok := existing[period]; ok && !IsBetterUpdate(update, current) {
+		return false, nil
+	}
+
+	if err := store.SaveLightClientUpdate(ctx, period, update); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,205 @@
+package lightclient
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// Generalized indices from the altair light client spec
+// (https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#constants).
+const (
+	finalizedRootGIndex        = 105
+	finalizedRootGIndexDepth   = 6
+	currentSyncCommitteeGIndex = 54
+	nextSyncCommitteeGIndex    = 55
+	syncCommitteeGIndexDepth   = 5
+)
+
+// subtreeIndex mirrors the spec's get_subtree_index: a generalized index's position
+// within its depth, with the leading depth-defining bit stripped off.
+func subtreeIndex(gIndex, depth uint64) uint64 {
+	return gIndex % (1 << depth)
+}
+
+// verifyParticipationAndSlots checks the two structural requirements every light client
+// update must satisfy before its signature is even looked at: at least one sync committee
+// member signed, and current_slot >= signature_slot > attested_slot >= finalized_slot.
+// finalizedSlot is 0 for updates that carry no finalized header, which trivially satisfies
+// the inequality.
+func verifyParticipationAndSlots(currentSlot, signatureSlot, attestedSlot, finalizedSlot primitives.Slot, syncAggregate *ethpb.SyncAggregate) error {
+	if syncAggregate.SyncCommitteeBits.Count() == 0 {
+		return errors.New("update has zero sync committee participation")
+	}
+	if !(currentSlot >= signatureSlot && signatureSlot > attestedSlot && attestedSlot >= finalizedSlot) {
+		return errors.Errorf(
+			"update slots out of order: current_slot=%d, signature_slot=%d, attested_slot=%d, finalized_slot=%d",
+			currentSlot, signatureSlot, attestedSlot, finalizedSlot,
+		)
+	}
+	return nil
+}
+
+// verifyFinalityBranch checks that finalizedHeader is proven into attestedHeader's state
+// root at FINALIZED_ROOT_GINDEX, the check that lets a finality update claim finality.
+func verifyFinalityBranch(attestedHeader, finalizedHeader interfaces.LightClientHeader, finalityBranch [][]byte) error {
+	finalizedRoot, err := finalizedHeader.Beacon().HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not hash finalized header")
+	}
+	depth := uint64(finalizedRootGIndexDepth)
+	if !stateutil.IsValidMerkleBranch(finalizedRoot[:], finalityBranch, depth, subtreeIndex(finalizedRootGIndex, depth), attestedHeader.Beacon().StateRoot) {
+		return errors.New("invalid finality branch Merkle proof")
+	}
+	return nil
+}
+
+// verifyNextSyncCommitteeBranch checks that nextSyncCommittee is proven into attestedHeader's
+// state root at NEXT_SYNC_COMMITTEE_GINDEX, the check a full periodic update needs before a
+// light client can adopt it as the committee for the next sync committee period.
+func verifyNextSyncCommitteeBranch(attestedHeader interfaces.LightClientHeader, nextSyncCommittee *ethpb.SyncCommittee, nextSyncCommitteeBranch [][]byte) error {
+	committeeRoot, err := nextSyncCommittee.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not hash next sync committee")
+	}
+	depth := uint64(syncCommitteeGIndexDepth)
+	if !stateutil.IsValidMerkleBranch(committeeRoot[:], nextSyncCommitteeBranch, depth, subtreeIndex(nextSyncCommitteeGIndex, depth), attestedHeader.Beacon().StateRoot) {
+		return errors.New("invalid next sync committee Merkle proof")
+	}
+	return nil
+}
+
+// verifySyncCommitteeSignature reconstructs the DOMAIN_SYNC_COMMITTEE signing domain at
+// forkVersion and checks syncAggregate's signature over attestedHeader's signing root,
+// aggregating the pubkeys of the committee members whose sync_committee_bits are set.
+func verifySyncCommitteeSignature(attestedHeader interfaces.LightClientHeader, syncAggregate *ethpb.SyncAggregate, committee *ethpb.SyncCommittee, forkVersion, genesisValidatorsRoot []byte) error {
+	bits := syncAggregate.SyncCommitteeBits
+	pubkeys := make([]bls.PublicKey, 0, bits.Count())
+	for i, pubkeyBytes := range committee.Pubkeys {
+		if !bits.BitAt(uint64(i)) {
+			continue
+		}
+		pubkey, err := bls.PublicKeyFromBytes(pubkeyBytes)
+		if err != nil {
+			return errors.Wrap(err, "could not deserialize sync committee pubkey")
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	if len(pubkeys) == 0 {
+		return errors.New("no participating sync committee pubkeys to verify against")
+	}
+
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainSyncCommittee, forkVersion, genesisValidatorsRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not compute sync committee domain")
+	}
+	signingRoot, err := signing.ComputeSigningRoot(attestedHeader.Beacon(), domain)
+	if err != nil {
+		return errors.Wrap(err, "could not compute signing root")
+	}
+
+	sig, err := bls.SignatureFromBytes(syncAggregate.SyncCommitteeSignature)
+	if err != nil {
+		return errors.Wrap(err, "could not deserialize sync committee signature")
+	}
+	if !sig.FastAggregateVerify(pubkeys, signingRoot) {
+		return errors.New("sync committee signature does not verify")
+	}
+	return nil
+}
+
+// VerifyLightClientFinalityUpdate self-checks a finality update before it's served,
+// following the VerifyGenericUpdate pattern: participation, slot ordering, the finality
+// branch Merkle proof, and the sync committee signature. committee must be the sync
+// committee active for update's attested period, and forkVersion the raw fork version
+// active at update.SignatureSlot().
+func VerifyLightClientFinalityUpdate(currentSlot primitives.Slot, update interfaces.LightClientFinalityUpdate, committee *ethpb.SyncCommittee, forkVersion, genesisValidatorsRoot []byte) error {
+	attested, finalized := update.AttestedHeader(), update.FinalizedHeader()
+	if err := verifyParticipationAndSlots(currentSlot, update.SignatureSlot(), attested.Beacon().Slot, finalized.Beacon().Slot, update.SyncAggregate()); err != nil {
+		return err
+	}
+	if err := verifyFinalityBranch(attested, finalized, update.FinalityBranch()); err != nil {
+		return err
+	}
+	return verifySyncCommitteeSignature(attested, update.SyncAggregate(), committee, forkVersion, genesisValidatorsRoot)
+}
+
+// VerifyLightClientUpdate self-checks a full periodic LightClientUpdate before it's stored
+// via SaveUpdate/IngestUpdate and served from BeaconDB.LightClientUpdates: participation,
+// slot ordering, the finality branch Merkle proof, the next-sync-committee branch Merkle
+// proof, and the sync committee signature. This is the update type light clients trust for
+// an entire sync committee period, so unlike VerifyLightClientFinalityUpdate it also checks
+// verifyNextSyncCommitteeBranch, the proof that lets a light client adopt
+// update.NextSyncCommittee() as the committee for the following period.
+func VerifyLightClientUpdate(currentSlot primitives.Slot, update interfaces.LightClientUpdate, committee *ethpb.SyncCommittee, forkVersion, genesisValidatorsRoot []byte) error {
+	attested, finalized := update.AttestedHeader(), update.FinalizedHeader()
+	if err := verifyParticipationAndSlots(currentSlot, update.SignatureSlot(), attested.Beacon().Slot, finalized.Beacon().Slot, update.SyncAggregate()); err != nil {
+		return err
+	}
+	if err := verifyFinalityBranch(attested, finalized, update.FinalityBranch()); err != nil {
+		return err
+	}
+	if err := verifyNextSyncCommitteeBranch(attested, update.NextSyncCommittee(), update.NextSyncCommitteeBranch()); err != nil {
+		return err
+	}
+	return verifySyncCommitteeSignature(attested, update.SyncAggregate(), committee, forkVersion, genesisValidatorsRoot)
+}
+
+// VerifyLightClientOptimisticUpdate self-checks an optimistic update before it's served:
+// participation, slot ordering, and the sync committee signature. Optimistic updates carry
+// no finalized header and so have no finality branch to verify; finalizedSlot is passed as
+// 0, which the slot ordering check treats as always satisfied.
+func VerifyLightClientOptimisticUpdate(currentSlot primitives.Slot, update interfaces.LightClientOptimisticUpdate, committee *ethpb.SyncCommittee, forkVersion, genesisValidatorsRoot []byte) error {
+	attested := update.AttestedHeader()
+	if err := verifyParticipationAndSlots(currentSlot, update.SignatureSlot(), attested.Beacon().Slot, 0, update.SyncAggregate()); err != nil {
+		return err
+	}
+	return verifySyncCommitteeSignature(attested, update.SyncAggregate(), committee, forkVersion, genesisValidatorsRoot)
+}
+
+// ForkVersionForSlot returns the fork version active at the epoch containing slot, walking
+// the fork schedule from newest to oldest activation epoch. It is exported so the rpc light
+// client Server's update verification, which the p2p gossip validator and the Portal Network
+// bridge also go through, derives the DOMAIN_SYNC_COMMITTEE signing domain from
+// signature_slot in one place. The p2p service keeps its own copy of this walk for computing
+// its topic fork digest.
+func ForkVersionForSlot(slot primitives.Slot) []byte {
+	cfg := params.BeaconConfig()
+	epoch := slots.ToEpoch(slot)
+	switch {
+	case epoch >= cfg.ElectraForkEpoch:
+		return cfg.ElectraForkVersion
+	case epoch >= cfg.DenebForkEpoch:
+		return cfg.DenebForkVersion
+	case epoch >= cfg.CapellaForkEpoch:
+		return cfg.CapellaForkVersion
+	case epoch >= cfg.BellatrixForkEpoch:
+		return cfg.BellatrixForkVersion
+	case epoch >= cfg.AltairForkEpoch:
+		return cfg.AltairForkVersion
+	default:
+		return cfg.GenesisForkVersion
+	}
+}
+
+// VerifyLightClientBootstrap self-checks a bootstrap before it's served: its current sync
+// committee must be proven into the header's state root. A bootstrap carries no signature
+// to verify, since it isn't attested to by a later block's sync aggregate the way updates
+// are - it's a direct snapshot of the state at blockRoot.
+func VerifyLightClientBootstrap(bootstrap interfaces.LightClientBootstrap) error {
+	committeeRoot, err := bootstrap.CurrentSyncCommittee().HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not hash current sync committee")
+	}
+	depth := uint64(syncCommitteeGIndexDepth)
+	if !stateutil.IsValidMerkleBranch(committeeRoot[:], bootstrap.CurrentSyncCommitteeBranch(), depth, subtreeIndex(currentSyncCommitteeGIndex, depth), bootstrap.Header().Beacon().StateRoot) {
+		return errors.New("invalid current sync committee Merkle proof")
+	}
+	return nil
+}
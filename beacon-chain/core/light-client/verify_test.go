@@ -0,0 +1,312 @@
+package lightclient
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+// verifyFakeHeader is the minimal interfaces.LightClientHeader implementation this file's
+// tests need: only Beacon() is ever read by the functions under test.
+type verifyFakeHeader struct {
+	interfaces.LightClientHeader
+	beacon *ethpb.BeaconBlockHeader
+}
+
+func (f *verifyFakeHeader) Beacon() *ethpb.BeaconBlockHeader {
+	return f.beacon
+}
+
+// verifyFakeFinalityUpdate implements interfaces.LightClientFinalityUpdate with whatever
+// combination of headers, participation and slots a test case needs.
+type verifyFakeFinalityUpdate struct {
+	interfaces.LightClientFinalityUpdate
+	attested      interfaces.LightClientHeader
+	finalized     interfaces.LightClientHeader
+	branch        [][]byte
+	bits          bitfield.Bitvector512
+	signatureSlot primitives.Slot
+}
+
+func (f *verifyFakeFinalityUpdate) AttestedHeader() interfaces.LightClientHeader { return f.attested }
+
+func (f *verifyFakeFinalityUpdate) FinalizedHeader() interfaces.LightClientHeader {
+	return f.finalized
+}
+
+func (f *verifyFakeFinalityUpdate) FinalityBranch() [][]byte { return f.branch }
+
+func (f *verifyFakeFinalityUpdate) SignatureSlot() primitives.Slot { return f.signatureSlot }
+
+func (f *verifyFakeFinalityUpdate) SyncAggregate() *ethpb.SyncAggregate {
+	return &ethpb.SyncAggregate{SyncCommitteeBits: f.bits, SyncCommitteeSignature: make([]byte, 96)}
+}
+
+// verifyFakeUpdate implements interfaces.LightClientUpdate, the full periodic update.
+type verifyFakeUpdate struct {
+	interfaces.LightClientUpdate
+	attested                interfaces.LightClientHeader
+	finalized               interfaces.LightClientHeader
+	finalityBranch          [][]byte
+	nextSyncCommittee       *ethpb.SyncCommittee
+	nextSyncCommitteeBranch [][]byte
+	bits                    bitfield.Bitvector512
+	signatureSlot           primitives.Slot
+}
+
+func (f *verifyFakeUpdate) AttestedHeader() interfaces.LightClientHeader { return f.attested }
+
+func (f *verifyFakeUpdate) FinalizedHeader() interfaces.LightClientHeader { return f.finalized }
+
+func (f *verifyFakeUpdate) FinalityBranch() [][]byte { return f.finalityBranch }
+
+func (f *verifyFakeUpdate) NextSyncCommittee() *ethpb.SyncCommittee { return f.nextSyncCommittee }
+
+func (f *verifyFakeUpdate) NextSyncCommitteeBranch() [][]byte { return f.nextSyncCommitteeBranch }
+
+func (f *verifyFakeUpdate) SignatureSlot() primitives.Slot { return f.signatureSlot }
+
+func (f *verifyFakeUpdate) SyncAggregate() *ethpb.SyncAggregate {
+	return &ethpb.SyncAggregate{SyncCommitteeBits: f.bits, SyncCommitteeSignature: make([]byte, 96)}
+}
+
+// verifyFakeOptimisticUpdate is the optimistic-update counterpart of
+// verifyFakeFinalityUpdate.
+type verifyFakeOptimisticUpdate struct {
+	interfaces.LightClientOptimisticUpdate
+	attested      interfaces.LightClientHeader
+	bits          bitfield.Bitvector512
+	signatureSlot primitives.Slot
+}
+
+func (f *verifyFakeOptimisticUpdate) AttestedHeader() interfaces.LightClientHeader {
+	return f.attested
+}
+
+func (f *verifyFakeOptimisticUpdate) SignatureSlot() primitives.Slot { return f.signatureSlot }
+
+func (f *verifyFakeOptimisticUpdate) SyncAggregate() *ethpb.SyncAggregate {
+	return &ethpb.SyncAggregate{SyncCommitteeBits: f.bits, SyncCommitteeSignature: make([]byte, 96)}
+}
+
+// verifyFakeBootstrap implements interfaces.LightClientBootstrap.
+type verifyFakeBootstrap struct {
+	interfaces.LightClientBootstrap
+	header    interfaces.LightClientHeader
+	committee *ethpb.SyncCommittee
+	branch    [][]byte
+}
+
+func (f *verifyFakeBootstrap) Header() interfaces.LightClientHeader { return f.header }
+
+func (f *verifyFakeBootstrap) CurrentSyncCommittee() *ethpb.SyncCommittee { return f.committee }
+
+func (f *verifyFakeBootstrap) CurrentSyncCommitteeBranch() [][]byte { return f.branch }
+
+func fullBits(n uint64) bitfield.Bitvector512 {
+	bits := bitfield.NewBitvector512()
+	for i := uint64(0); i < n; i++ {
+		bits.SetBitAt(i, true)
+	}
+	return bits
+}
+
+// hashableSyncCommittee returns a sync committee with the 512 48-byte pubkeys and the
+// 48-byte aggregate pubkey its HashTreeRoot requires, so a test reaches the Merkle proof
+// check instead of failing on the hash.
+func hashableSyncCommittee() *ethpb.SyncCommittee {
+	pubkeys := make([][]byte, 512)
+	for i := range pubkeys {
+		pubkeys[i] = make([]byte, 48)
+	}
+	return &ethpb.SyncCommittee{Pubkeys: pubkeys, AggregatePubkey: make([]byte, 48)}
+}
+
+// hashableHeader returns a beacon block header at slot with the 32-byte roots its
+// HashTreeRoot requires.
+func hashableHeader(slot primitives.Slot) *ethpb.BeaconBlockHeader {
+	return &ethpb.BeaconBlockHeader{Slot: slot, ParentRoot: make([]byte, 32), StateRoot: make([]byte, 32), BodyRoot: make([]byte, 32)}
+}
+
+func TestVerifyParticipationAndSlots(t *testing.T) {
+	agg := &ethpb.SyncAggregate{SyncCommitteeBits: fullBits(300)}
+	zeroAgg := &ethpb.SyncAggregate{SyncCommitteeBits: bitfield.NewBitvector512()}
+
+	tests := []struct {
+		name                                                    string
+		currentSlot, signatureSlot, attestedSlot, finalizedSlot primitives.Slot
+		agg                                                     *ethpb.SyncAggregate
+		wantErr                                                 bool
+	}{
+		{"valid ordering", 10, 9, 8, 7, agg, false},
+		{"zero participation rejected", 10, 9, 8, 7, zeroAgg, true},
+		{"signature slot not after attested slot", 10, 8, 8, 7, agg, true},
+		{"attested slot before finalized slot", 10, 9, 6, 7, agg, true},
+		{"signature slot after current slot", 9, 10, 8, 7, agg, true},
+		{"no finalized header still valid", 10, 9, 8, 0, agg, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyParticipationAndSlots(tt.currentSlot, tt.signatureSlot, tt.attestedSlot, tt.finalizedSlot, tt.agg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifyParticipationAndSlots() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSubtreeIndex(t *testing.T) {
+	tests := []struct {
+		gIndex, depth, want uint64
+	}{
+		{currentSyncCommitteeGIndex, syncCommitteeGIndexDepth, currentSyncCommitteeGIndex - (1 << syncCommitteeGIndexDepth)},
+		{nextSyncCommitteeGIndex, syncCommitteeGIndexDepth, nextSyncCommitteeGIndex - (1 << syncCommitteeGIndexDepth)},
+		{finalizedRootGIndex, finalizedRootGIndexDepth, finalizedRootGIndex - (1 << finalizedRootGIndexDepth)},
+	}
+	for _, tt := range tests {
+		if got := subtreeIndex(tt.gIndex, tt.depth); got != tt.want {
+			t.Errorf("subtreeIndex(%d, %d) = %d, want %d", tt.gIndex, tt.depth, got, tt.want)
+		}
+	}
+}
+
+func TestVerifyFinalityBranch_RejectsMismatchedProof(t *testing.T) {
+	// A branch of zero-filled siblings has a vanishingly small chance of ever hashing up to
+	// an arbitrary, unrelated state root - this exercises the rejection path without needing
+	// to reproduce the real SSZ HashTreeRoot/Merkle implementation to build a matching one.
+	branch := make([][]byte, finalizedRootGIndexDepth)
+	for i := range branch {
+		branch[i] = make([]byte, 32)
+	}
+	attested := &verifyFakeHeader{beacon: &ethpb.BeaconBlockHeader{StateRoot: make([]byte, 32)}}
+	finalized := &verifyFakeHeader{beacon: hashableHeader(7)}
+
+	if err := verifyFinalityBranch(attested, finalized, branch); err == nil {
+		t.Fatal("expected an error for a finality branch that does not prove into the attested state root")
+	}
+}
+
+func TestVerifyNextSyncCommitteeBranch_RejectsMismatchedProof(t *testing.T) {
+	branch := make([][]byte, syncCommitteeGIndexDepth)
+	for i := range branch {
+		branch[i] = make([]byte, 32)
+	}
+	attested := &verifyFakeHeader{beacon: &ethpb.BeaconBlockHeader{StateRoot: make([]byte, 32)}}
+	committee := hashableSyncCommittee()
+
+	if err := verifyNextSyncCommitteeBranch(attested, committee, branch); err == nil {
+		t.Fatal("expected an error for a next sync committee branch that does not prove into the attested state root")
+	}
+}
+
+func TestVerifySyncCommitteeSignature_RejectsZeroParticipation(t *testing.T) {
+	attested := &verifyFakeHeader{beacon: &ethpb.BeaconBlockHeader{}}
+	agg := &ethpb.SyncAggregate{SyncCommitteeBits: bitfield.NewBitvector512(), SyncCommitteeSignature: make([]byte, 96)}
+	committee := &ethpb.SyncCommittee{Pubkeys: make([][]byte, 512)}
+
+	if err := verifySyncCommitteeSignature(attested, agg, committee, nil, nil); err == nil {
+		t.Fatal("expected an error when no sync committee member participated")
+	}
+}
+
+func TestVerifySyncCommitteeSignature_RejectsMalformedSignature(t *testing.T) {
+	attested := &verifyFakeHeader{beacon: &ethpb.BeaconBlockHeader{}}
+	bits := fullBits(2)
+	agg := &ethpb.SyncAggregate{SyncCommitteeBits: bits, SyncCommitteeSignature: []byte("not a real signature")}
+	// The pubkeys must deserialize so the rejection comes from the signature, not from them.
+	sk, err := bls.RandKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubkeys := make([][]byte, 512)
+	for i := range pubkeys {
+		pubkeys[i] = sk.PublicKey().Marshal()
+	}
+	committee := &ethpb.SyncCommittee{Pubkeys: pubkeys}
+
+	if err := verifySyncCommitteeSignature(attested, agg, committee, make([]byte, 4), make([]byte, 32)); err == nil {
+		t.Fatal("expected an error for a signature that does not deserialize")
+	}
+}
+
+func TestVerifyLightClientFinalityUpdate_RejectsOutOfOrderSlot(t *testing.T) {
+	update := &verifyFakeFinalityUpdate{
+		attested:      &verifyFakeHeader{beacon: &ethpb.BeaconBlockHeader{Slot: 8}},
+		finalized:     &verifyFakeHeader{beacon: &ethpb.BeaconBlockHeader{Slot: 7}},
+		branch:        make([][]byte, finalizedRootGIndexDepth),
+		bits:          fullBits(300),
+		signatureSlot: 8, // not strictly after attested_slot - must be rejected before any proof is checked
+	}
+	committee := &ethpb.SyncCommittee{Pubkeys: make([][]byte, 512)}
+	if err := VerifyLightClientFinalityUpdate(10, update, committee, nil, nil); err == nil {
+		t.Fatal("expected an error for an out-of-order signature slot")
+	}
+}
+
+func TestVerifyLightClientOptimisticUpdate_RejectsZeroParticipation(t *testing.T) {
+	update := &verifyFakeOptimisticUpdate{
+		attested:      &verifyFakeHeader{beacon: &ethpb.BeaconBlockHeader{Slot: 8}},
+		bits:          bitfield.NewBitvector512(),
+		signatureSlot: 9,
+	}
+	committee := &ethpb.SyncCommittee{Pubkeys: make([][]byte, 512)}
+	if err := VerifyLightClientOptimisticUpdate(10, update, committee, nil, nil); err == nil {
+		t.Fatal("expected an error for an update with zero sync committee participation")
+	}
+}
+
+func TestVerifyLightClientUpdate_RejectsOutOfOrderSlot(t *testing.T) {
+	update := &verifyFakeUpdate{
+		attested:                &verifyFakeHeader{beacon: &ethpb.BeaconBlockHeader{Slot: 8}},
+		finalized:               &verifyFakeHeader{beacon: &ethpb.BeaconBlockHeader{Slot: 7}},
+		finalityBranch:          make([][]byte, finalizedRootGIndexDepth),
+		nextSyncCommittee:       &ethpb.SyncCommittee{Pubkeys: make([][]byte, 0)},
+		nextSyncCommitteeBranch: make([][]byte, syncCommitteeGIndexDepth),
+		bits:                    fullBits(300),
+		signatureSlot:           8, // not strictly after attested_slot - must be rejected before any proof is checked
+	}
+	committee := &ethpb.SyncCommittee{Pubkeys: make([][]byte, 512)}
+	if err := VerifyLightClientUpdate(10, update, committee, nil, nil); err == nil {
+		t.Fatal("expected an error for an out-of-order signature slot")
+	}
+}
+
+// TestVerifyLightClientUpdate_RejectsMismatchedProof checks that an update with valid slot
+// ordering is still rejected when its Merkle proofs do not verify against the attested
+// state root. VerifyLightClientUpdate checks the finality branch before the
+// next-sync-committee branch, so with both branches zero-filled the rejection comes from
+// the finality branch.
+func TestVerifyLightClientUpdate_RejectsMismatchedProof(t *testing.T) {
+	update := &verifyFakeUpdate{
+		attested:                &verifyFakeHeader{beacon: &ethpb.BeaconBlockHeader{Slot: 8, StateRoot: make([]byte, 32)}},
+		finalized:               &verifyFakeHeader{beacon: hashableHeader(7)},
+		finalityBranch:          make([][]byte, finalizedRootGIndexDepth),
+		nextSyncCommittee:       &ethpb.SyncCommittee{Pubkeys: make([][]byte, 0)},
+		nextSyncCommitteeBranch: make([][]byte, syncCommitteeGIndexDepth),
+		bits:                    fullBits(300),
+		signatureSlot:           9,
+	}
+	committee := &ethpb.SyncCommittee{Pubkeys: make([][]byte, 512)}
+	if err := VerifyLightClientUpdate(10, update, committee, nil, nil); err == nil {
+		t.Fatal("expected an error for a proof that does not verify against the attested state root")
+	}
+}
+
+func TestVerifyLightClientBootstrap_RejectsMismatchedProof(t *testing.T) {
+	branch := make([][]byte, syncCommitteeGIndexDepth)
+	for i := range branch {
+		branch[i] = make([]byte, 32)
+	}
+	bootstrap := &verifyFakeBootstrap{
+		header:    &verifyFakeHeader{beacon: &ethpb.BeaconBlockHeader{StateRoot: make([]byte, 32)}},
+		committee: hashableSyncCommittee(),
+		branch:    branch,
+	}
+	if err := VerifyLightClientBootstrap(bootstrap); err == nil {
+		t.Fatal("expected an error for a current sync committee branch that does not prove into the header's state root")
+	}
+}
@@ -0,0 +1,44 @@
+package p2p
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	lightclient "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/light-client"
+)
+
+// Broadcaster is the subset of the service's gossipsub plumbing that
+// PublishLightClientFinalityUpdate and PublishLightClientOptimisticUpdate need: publishing
+// already-encoded bytes on a gossipsub topic. It's declared as an interface, rather than
+// depending on go-libp2p-pubsub's *pubsub.PubSub directly, so a unit test can exercise the
+// dedupe logic around it without standing up a real libp2p host.
+type Broadcaster interface {
+	Publish(ctx context.Context, topic string, data []byte) error
+}
+
+// Config bundles the dependencies the p2p Service needs to reach into higher beacon-chain
+// layers from gossip and req-resp handlers, mirroring the dependency-bundling pattern the
+// Portal Network Config and the rpc light client Server already use.
+type Config struct {
+	// StateNotifier drives subscribeLightClientUpdates: whenever a new canonical block is
+	// processed, it rebuilds and gossips the current finality/optimistic updates.
+	StateNotifier statefeed.Notifier
+	// ChainInfoFetcher supplies the genesis validators root and current slot
+	// currentForkDigest needs to namespace every topic and req-resp domain this service
+	// registers.
+	ChainInfoFetcher blockchain.ChainInfoFetcher
+	// Broadcaster publishes gossip messages; see broadcastLightClientUpdate.
+	Broadcaster Broadcaster
+	// BeaconDB backs the light_client_updates_by_range req-resp handler, letting it read
+	// stored updates directly instead of round-tripping through the rpc layer.
+	BeaconDB db.ReadOnlyDatabase
+
+	// LightClient exposes the rpc light client Server's shared producers (Bootstrap,
+	// FinalityUpdate, OptimisticUpdate, EncodeUpdateWithForkDigest) and SSE ingestion
+	// (IngestFinalityUpdate, IngestOptimisticUpdate) so the gossip and req-resp handlers in
+	// subscriber_light_client.go and rpc_light_client.go share a single source of truth
+	// with the beacon-APIs handlers instead of duplicating them.
+	LightClient *lightclient.Server
+}
@@ -0,0 +1,95 @@
+package p2p
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+)
+
+const (
+	// RPCLightClientBootstrapTopic is the req-resp domain a peer uses to fetch a
+	// LightClientBootstrap for a given block root, mirroring the beacon-APIs endpoint of
+	// the same name but over libp2p instead of HTTP.
+	RPCLightClientBootstrapTopic = "/eth2/beacon_chain/req/light_client_bootstrap/1"
+	// RPCLightClientUpdatesByRangeTopic fetches a contiguous run of best updates.
+	RPCLightClientUpdatesByRangeTopic = "/eth2/beacon_chain/req/light_client_updates_by_range/1"
+)
+
+// lightClientUpdatesByRangeRequest mirrors the query parameters GetLightClientUpdatesByRange
+// accepts over HTTP: a start period and a count, capped server-side the same way.
+type lightClientUpdatesByRangeRequest struct {
+	StartPeriod uint64
+	Count       uint64
+}
+
+// registerRPCLightClientHandlers registers the light_client_bootstrap and
+// light_client_updates_by_range req-resp domains, gated behind EnableLightClient like the
+// rest of the light client surface.
+func (s *Service) registerRPCLightClientHandlers() {
+	if !features.Get().EnableLightClient {
+		return
+	}
+	s.registerRPCHandler(RPCLightClientBootstrapTopic, s.lightClientBootstrapRPCHandler)
+	s.registerRPCHandler(RPCLightClientUpdatesByRangeTopic, s.lightClientUpdatesByRangeRPCHandler)
+}
+
+// lightClientBootstrapRPCHandler answers a light_client_bootstrap request by delegating
+// to the same producer the beacon-APIs GetLightClientBootstrap handler uses.
+func (s *Service) lightClientBootstrapRPCHandler(ctx context.Context, msg interface{}, stream network.Stream) error {
+	blockRoot, ok := msg.([32]byte)
+	if !ok {
+		return errors.New("message is not a block root")
+	}
+
+	bootstrap, err := s.cfg.LightClient.Bootstrap(ctx, blockRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not build light client bootstrap")
+	}
+
+	data, err := bootstrap.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal light client bootstrap")
+	}
+	return s.writeRPCResponse(stream, data)
+}
+
+// lightClientUpdatesByRangeRPCHandler answers a light_client_updates_by_range request by
+// pulling the requested period range out of BeaconDB.LightClientUpdates, framing each
+// element the same way the beacon-APIs SSZ response does.
+func (s *Service) lightClientUpdatesByRangeRPCHandler(ctx context.Context, msg interface{}, stream network.Stream) error {
+	req, ok := msg.(*lightClientUpdatesByRangeRequest)
+	if !ok {
+		return errors.New("message is not a light client updates-by-range request")
+	}
+	if req.Count == 0 {
+		return errors.New("count must be greater than 0")
+	}
+	count := req.Count
+	if count > params.BeaconConfig().MaxRequestLightClientUpdates {
+		count = params.BeaconConfig().MaxRequestLightClientUpdates
+	}
+
+	endPeriod := req.StartPeriod + count - 1
+	updatesMap, err := s.cfg.BeaconDB.LightClientUpdates(ctx, req.StartPeriod, endPeriod)
+	if err != nil {
+		return errors.Wrap(err, "could not get light client updates from DB")
+	}
+
+	for i := req.StartPeriod; i <= endPeriod; i++ {
+		update, ok := updatesMap[i]
+		if !ok {
+			break
+		}
+		encoded, err := s.cfg.LightClient.EncodeUpdateWithForkDigest(update)
+		if err != nil {
+			return errors.Wrap(err, "could not encode light client update")
+		}
+		if err := s.writeRPCResponse(stream, encoded); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package p2p
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+
+	lru "github.com/hashicorp/golang-lru"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// Service implements the light client slice of Prysm's p2p service: the gossip topics and
+// req-resp domains subscriber_light_client.go and rpc_light_client.go register ride on the
+// subscribe/registerRPCHandler/writeRPCResponse plumbing this file owns - construction,
+// startup wiring, the fork-choice-driven gossip publisher, and the gossipsub/stream
+// transport those two files build their handlers on top of.
+type Service struct {
+	cfg    *Config
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	host   host.Host
+	pubsub *pubsub.PubSub
+
+	seenLightClientFinalityUpdateCache   *lru.Cache
+	seenLightClientOptimisticUpdateCache *lru.Cache
+}
+
+// NewService returns an uninitialized p2p Service bound to the given host and gossipsub
+// router. Call Start to register the light client gossip topics and req-resp domains.
+func NewService(ctx context.Context, h host.Host, ps *pubsub.PubSub, cfg *Config) (*Service, error) {
+	if cfg == nil {
+		return nil, errors.New("nil config")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		cfg:                                  cfg,
+		ctx:                                  ctx,
+		cancel:                               cancel,
+		host:                                 h,
+		pubsub:                               ps,
+		seenLightClientFinalityUpdateCache:   newLightClientSeenCache(),
+		seenLightClientOptimisticUpdateCache: newLightClientSeenCache(),
+	}, nil
+}
+
+// Start registers the light client gossip topics and req-resp domains, gated behind the
+// same EnableLightClient feature flag the beacon-APIs handlers use, and starts the
+// background publisher that keeps gossiped updates current off fork-choice events.
+func (s *Service) Start() {
+	if !features.Get().EnableLightClient {
+		return
+	}
+	digest := s.currentForkDigest()
+	s.registerLightClientSubscribers(digest)
+	s.registerRPCLightClientHandlers()
+	go s.subscribeLightClientUpdates(digest)
+}
+
+// Stop tears down the service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// currentForkDigest computes the fork digest for the current epoch, the same four bytes
+// every gossip topic and req-resp domain this service registers is namespaced under.
+func (s *Service) currentForkDigest() [4]byte {
+	genesisValidatorsRoot := s.cfg.ChainInfoFetcher.GenesisValidatorsRoot()
+	forkVersion := forkVersionForSlot(s.cfg.ChainInfoFetcher.CurrentSlot())
+	digest, err := signing.ComputeForkDigest(forkVersion, genesisValidatorsRoot[:])
+	if err != nil {
+		log.WithError(err).Error("Could not compute fork digest")
+		return [4]byte{}
+	}
+	return digest
+}
+
+// forkVersionForSlot returns the fork version active at the epoch containing slot, walking
+// the fork schedule from newest to oldest activation epoch.
+func forkVersionForSlot(slot primitives.Slot) []byte {
+	cfg := params.BeaconConfig()
+	epoch := slots.ToEpoch(slot)
+	switch {
+	case epoch >= cfg.ElectraForkEpoch:
+		return cfg.ElectraForkVersion
+	case epoch >= cfg.DenebForkEpoch:
+		return cfg.DenebForkVersion
+	case epoch >= cfg.CapellaForkEpoch:
+		return cfg.CapellaForkVersion
+	case epoch >= cfg.BellatrixForkEpoch:
+		return cfg.BellatrixForkVersion
+	case epoch >= cfg.AltairForkEpoch:
+		return cfg.AltairForkVersion
+	default:
+		return cfg.GenesisForkVersion
+	}
+}
+
+// broadcastLightClientUpdate publishes data on topic via the configured Broadcaster. It's
+// the seam PublishLightClientFinalityUpdate and PublishLightClientOptimisticUpdate gossip
+// through, kept as its own method so tests can swap in a fake Broadcaster.
+func (s *Service) broadcastLightClientUpdate(ctx context.Context, topic string, data []byte) error {
+	if s.cfg.Broadcaster == nil {
+		return errors.New("no broadcaster configured")
+	}
+	return s.cfg.Broadcaster.Publish(ctx, topic, data)
+}
+
+// subscribe joins a gossipsub topic, registers validator as its topic validator, and hands
+// every message that passes validation to handle. validator and handle share the
+// decode-once/verify-once handoff every gossip topic on this service uses: validator
+// type-asserts the decoded payload out of msg.ValidatorData, and handle receives that same
+// value rather than decoding the raw bytes a second time.
+func (s *Service) subscribe(topic string, validator func(ctx context.Context, topic string, msg *pubsub.Message) (pubsub.ValidationResult, error), handle func(ctx context.Context, msg interface{}) error) {
+	wrapped := func(ctx context.Context, _ peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+		result, err := validator(ctx, topic, msg)
+		if err != nil {
+			log.WithError(err).WithField("topic", topic).Debug("Gossip message failed validation")
+		}
+		return result
+	}
+	if err := s.pubsub.RegisterTopicValidator(topic, pubsub.ValidatorEx(wrapped)); err != nil {
+		log.WithError(err).WithField("topic", topic).Error("Could not register topic validator")
+		return
+	}
+	joined, err := s.pubsub.Join(topic)
+	if err != nil {
+		log.WithError(err).WithField("topic", topic).Error("Could not join topic")
+		return
+	}
+	sub, err := joined.Subscribe()
+	if err != nil {
+		log.WithError(err).WithField("topic", topic).Error("Could not subscribe to topic")
+		return
+	}
+	go func() {
+		for {
+			msg, err := sub.Next(s.ctx)
+			if err != nil {
+				return
+			}
+			if err := handle(s.ctx, msg.ValidatorData); err != nil {
+				log.WithError(err).WithField("topic", topic).Error("Could not handle gossip message")
+			}
+		}
+	}()
+}
+
+// registerRPCHandler registers handle as the stream handler for topic, decoding each
+// incoming request with decodeRPCRequest before calling handle with the result.
+func (s *Service) registerRPCHandler(topic string, handle func(ctx context.Context, msg interface{}, stream network.Stream) error) {
+	s.host.SetStreamHandler(protocol.ID(topic), func(stream network.Stream) {
+		defer func() {
+			if err := stream.Close(); err != nil {
+				log.WithError(err).Debug("Could not close stream")
+			}
+		}()
+		msg, err := decodeRPCRequest(topic, stream)
+		if err != nil {
+			log.WithError(err).WithField("topic", topic).Debug("Could not decode RPC request")
+			return
+		}
+		if err := handle(s.ctx, msg, stream); err != nil {
+			log.WithError(err).WithField("topic", topic).Debug("Could not handle RPC request")
+		}
+	})
+}
+
+// decodeRPCRequest reads topic's request payload off stream, dispatching on the two req-resp
+// domains this service registers.
+func decodeRPCRequest(topic string, stream network.Stream) (interface{}, error) {
+	switch topic {
+	case RPCLightClientBootstrapTopic:
+		var root [32]byte
+		if _, err := io.ReadFull(stream, root[:]); err != nil {
+			return nil, errors.Wrap(err, "could not read block root")
+		}
+		return root, nil
+	case RPCLightClientUpdatesByRangeTopic:
+		var buf [16]byte
+		if _, err := io.ReadFull(stream, buf[:]); err != nil {
+			return nil, errors.Wrap(err, "could not read updates-by-range request")
+		}
+		return &lightClientUpdatesByRangeRequest{
+			StartPeriod: binary.LittleEndian.Uint64(buf[:8]),
+			Count:       binary.LittleEndian.Uint64(buf[8:]),
+		}, nil
+	default:
+		return nil, errors.Errorf("unknown RPC topic %q", topic)
+	}
+}
+
+// writeRPCResponse writes data to stream framed with a fixed-width length prefix, the wire
+// format every req-resp domain this service registers uses.
+func (s *Service) writeRPCResponse(stream network.Stream, data []byte) error {
+	var lengthPrefix [8]byte
+	binary.LittleEndian.PutUint64(lengthPrefix[:], uint64(len(data)))
+	if _, err := stream.Write(lengthPrefix[:]); err != nil {
+		return errors.Wrap(err, "could not write response length prefix")
+	}
+	if _, err := stream.Write(data); err != nil {
+		return errors.Wrap(err, "could not write response data")
+	}
+	return nil
+}
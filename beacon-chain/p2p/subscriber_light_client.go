@@ -0,0 +1,264 @@
+// This file and rpc_light_client.go add the light client gossip topics and req-resp domains
+// onto Service and Config (defined in service.go and config.go): they ride on the
+// subscribe/registerRPCHandler/writeRPCResponse plumbing in service.go, and are registered
+// from Service.Start. They reach the rest of the beacon node through the Config fields
+// s.cfg.LightClient, s.cfg.StateNotifier, s.cfg.Broadcaster, and s.cfg.BeaconDB.
+package p2p
+
+import (
+	"context"
+	"fmt"
+
+	lru "github.com/hashicorp/golang-lru"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+const (
+	// LightClientFinalityUpdateTopicFormat follows the same "/eth2/%x/<name>/ssz_snappy"
+	// shape as every other gossipsub topic registered by this service.
+	LightClientFinalityUpdateTopicFormat = "/eth2/%x/light_client_finality_update/ssz_snappy"
+	// LightClientOptimisticUpdateTopicFormat is the optimistic-update counterpart.
+	LightClientOptimisticUpdateTopicFormat = "/eth2/%x/light_client_optimistic_update/ssz_snappy"
+
+	// lightClientSeenCacheSize bounds the dedupe cache so a node that serves many
+	// identical updates (common right after a period boundary) doesn't republish them.
+	lightClientSeenCacheSize = 128
+)
+
+// lightClientUpdateKey is the dedupe key for gossiped finality/optimistic updates: two
+// updates built from the same attested/finalized slot with the same participation are
+// considered duplicates even if they were derived independently.
+type lightClientUpdateKey struct {
+	attestedSlot  uint64
+	finalizedSlot uint64
+	participation uint64
+}
+
+// registerLightClientSubscribers wires up the light_client_finality_update and
+// light_client_optimistic_update gossipsub topics, gated behind the EnableLightClient
+// feature flag the beacon-APIs handlers already use. It is called from Start.
+func (s *Service) registerLightClientSubscribers(digest [4]byte) {
+	if !features.Get().EnableLightClient {
+		return
+	}
+
+	s.subscribe(
+		fmt.Sprintf(LightClientFinalityUpdateTopicFormat, digest),
+		s.validateLightClientFinalityUpdate,
+		s.lightClientFinalityUpdateSubscriber,
+	)
+	s.subscribe(
+		fmt.Sprintf(LightClientOptimisticUpdateTopicFormat, digest),
+		s.validateLightClientOptimisticUpdate,
+		s.lightClientOptimisticUpdateSubscriber,
+	)
+}
+
+// PublishLightClientFinalityUpdate gossips update on light_client_finality_update,
+// deduping against recently published updates for the same attested/finalized slot and
+// participation count. Call sites are expected to have already produced update via
+// suitableBlock, matching the inputs the beacon-APIs finality update handler uses.
+func (s *Service) PublishLightClientFinalityUpdate(ctx context.Context, digest [4]byte, update interfaces.LightClientFinalityUpdate) error {
+	key := lightClientUpdateKey{
+		attestedSlot:  uint64(update.AttestedHeader().Beacon().Slot),
+		finalizedSlot: uint64(update.FinalizedHeader().Beacon().Slot),
+		participation: update.SyncAggregate().SyncCommitteeBits.Count(),
+	}
+	if _, ok := s.seenLightClientFinalityUpdateCache.Get(key); ok {
+		return nil
+	}
+	s.seenLightClientFinalityUpdateCache.Add(key, true)
+
+	data, err := update.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal finality update")
+	}
+	return s.broadcastLightClientUpdate(ctx, fmt.Sprintf(LightClientFinalityUpdateTopicFormat, digest), data)
+}
+
+// PublishLightClientOptimisticUpdate gossips update on light_client_optimistic_update,
+// deduping the same way PublishLightClientFinalityUpdate does.
+func (s *Service) PublishLightClientOptimisticUpdate(ctx context.Context, digest [4]byte, update interfaces.LightClientOptimisticUpdate) error {
+	key := lightClientUpdateKey{
+		attestedSlot:  uint64(update.AttestedHeader().Beacon().Slot),
+		participation: update.SyncAggregate().SyncCommitteeBits.Count(),
+	}
+	if _, ok := s.seenLightClientOptimisticUpdateCache.Get(key); ok {
+		return nil
+	}
+	s.seenLightClientOptimisticUpdateCache.Add(key, true)
+
+	data, err := update.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal optimistic update")
+	}
+	return s.broadcastLightClientUpdate(ctx, fmt.Sprintf(LightClientOptimisticUpdateTopicFormat, digest), data)
+}
+
+// validateLightClientFinalityUpdate runs lightclient.VerifyLightClientFinalityUpdate (via
+// the Server's VerifyFinalityUpdate, the same self-check the beacon-APIs handlers run on
+// what they're about to serve) against a gossiped update before it's relayed or folded
+// into the SSE cache. Without this, any peer could gossip a fabricated update - a bogus
+// signature, or garbage participation bits crafted to win IsBetterUpdate - and have it
+// accepted, relayed to the mesh, and streamed to downstream light clients unverified.
+func (s *Service) validateLightClientFinalityUpdate(ctx context.Context, _ string, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	if !features.Get().EnableLightClient {
+		return pubsub.ValidationReject, errors.New("light client feature flag is not enabled")
+	}
+	update, ok := msg.ValidatorData.(interfaces.LightClientFinalityUpdate)
+	if !ok {
+		return pubsub.ValidationReject, errors.New("message is not a light client finality update")
+	}
+	if err := s.cfg.LightClient.VerifyFinalityUpdate(ctx, update); err != nil {
+		return pubsub.ValidationReject, errors.Wrap(err, "could not verify gossiped light client finality update")
+	}
+	return pubsub.ValidationAccept, nil
+}
+
+// validateLightClientOptimisticUpdate is the optimistic-update counterpart of
+// validateLightClientFinalityUpdate.
+func (s *Service) validateLightClientOptimisticUpdate(ctx context.Context, _ string, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	if !features.Get().EnableLightClient {
+		return pubsub.ValidationReject, errors.New("light client feature flag is not enabled")
+	}
+	update, ok := msg.ValidatorData.(interfaces.LightClientOptimisticUpdate)
+	if !ok {
+		return pubsub.ValidationReject, errors.New("message is not a light client optimistic update")
+	}
+	if err := s.cfg.LightClient.VerifyOptimisticUpdate(ctx, update); err != nil {
+		return pubsub.ValidationReject, errors.Wrap(err, "could not verify gossiped light client optimistic update")
+	}
+	return pubsub.ValidationAccept, nil
+}
+
+// lightClientFinalityUpdateSubscriber merges a gossiped finality update into the rpc
+// server's SSE cache via IngestFinalityUpdate, which only lets it replace what's already
+// cached if it ranks strictly better. This keeps a node that's behind from clobbering
+// its own freshly-derived update with a stale one relayed from a peer. It never reaches
+// SaveUpdate/BeaconDB.LightClientUpdates: a finality update carries no next-sync-committee
+// proof, so it can't be promoted to the full periodic LightClientUpdate that ranking path
+// stores - only the locally-derived update FinalityUpdate() builds can be.
+func (s *Service) lightClientFinalityUpdateSubscriber(ctx context.Context, msg interface{}) error {
+	update, ok := msg.(interfaces.LightClientFinalityUpdate)
+	if !ok {
+		return errors.New("message is not a light client finality update")
+	}
+	log.WithField("attestedSlot", update.AttestedHeader().Beacon().Slot).Debug("Received light client finality update over gossip")
+
+	attestedRoot, err := update.AttestedHeader().Beacon().HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not hash attested header")
+	}
+	data, err := structs.LightClientFinalityUpdateFromConsensus(update)
+	if err != nil {
+		return errors.Wrap(err, "could not convert light client finality update")
+	}
+	resp := &structs.LightClientFinalityUpdateResponse{
+		Version: version.String(update.Version()),
+		Data:    data,
+	}
+	_, err = s.cfg.LightClient.IngestFinalityUpdate(resp, attestedRoot, update)
+	return err
+}
+
+// lightClientOptimisticUpdateSubscriber is the optimistic-update counterpart of
+// lightClientFinalityUpdateSubscriber.
+func (s *Service) lightClientOptimisticUpdateSubscriber(ctx context.Context, msg interface{}) error {
+	update, ok := msg.(interfaces.LightClientOptimisticUpdate)
+	if !ok {
+		return errors.New("message is not a light client optimistic update")
+	}
+	log.WithField("attestedSlot", update.AttestedHeader().Beacon().Slot).Debug("Received light client optimistic update over gossip")
+
+	attestedRoot, err := update.AttestedHeader().Beacon().HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not hash attested header")
+	}
+	data, err := structs.LightClientOptimisticUpdateFromConsensus(update)
+	if err != nil {
+		return errors.Wrap(err, "could not convert light client optimistic update")
+	}
+	resp := &structs.LightClientOptimisticUpdateResponse{
+		Version: version.String(update.Version()),
+		Data:    data,
+	}
+	_, err = s.cfg.LightClient.IngestOptimisticUpdate(resp, attestedRoot, update)
+	return err
+}
+
+// subscribeLightClientUpdates runs for the lifetime of the service, rebuilding and
+// gossiping the current finality and optimistic updates every time a new block is
+// processed by fork choice. It mirrors subscribeSuitableBlockCache's use of
+// statefeed.BlockProcessed as the trigger, since a freshly produced finality/optimistic
+// update is exactly what suitableBlock may have just recomputed off that same event.
+func (s *Service) subscribeLightClientUpdates(digest [4]byte) {
+	if !features.Get().EnableLightClient {
+		return
+	}
+	ch := make(chan *statefeed.Event, 1)
+	sub := s.cfg.StateNotifier.StateFeed().Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case evt := <-ch:
+			if evt.Type != statefeed.BlockProcessed {
+				continue
+			}
+			data, ok := evt.Data.(*statefeed.BlockProcessedData)
+			if !ok || !data.Verified {
+				continue
+			}
+			s.publishLightClientUpdates(s.ctx, digest)
+		case err := <-sub.Err():
+			log.WithError(err).Error("Could not subscribe to state feed for light client updates")
+			return
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// publishLightClientUpdates rebuilds the current finality and optimistic updates off
+// suitableBlock, folds each into the rpc server's SSE cache via Ingest*Update, and
+// gossips whichever one turns out to be new. Errors are logged rather than returned
+// since this runs off a background event loop with no caller to report to.
+func (s *Service) publishLightClientUpdates(ctx context.Context, digest [4]byte) {
+	finalityResp, attestedRoot, finalityUpdate, err := s.cfg.LightClient.FinalityUpdate(ctx)
+	if err != nil {
+		log.WithError(err).Debug("Could not build light client finality update")
+	} else {
+		if _, err := s.cfg.LightClient.IngestFinalityUpdate(finalityResp, attestedRoot, finalityUpdate); err != nil {
+			log.WithError(err).Error("Could not ingest light client finality update")
+		}
+		if err := s.PublishLightClientFinalityUpdate(ctx, digest, finalityUpdate); err != nil {
+			log.WithError(err).Error("Could not publish light client finality update")
+		}
+	}
+
+	optimisticResp, attestedRoot, optimisticUpdate, err := s.cfg.LightClient.OptimisticUpdate(ctx)
+	if err != nil {
+		log.WithError(err).Debug("Could not build light client optimistic update")
+		return
+	}
+	if _, err := s.cfg.LightClient.IngestOptimisticUpdate(optimisticResp, attestedRoot, optimisticUpdate); err != nil {
+		log.WithError(err).Error("Could not ingest light client optimistic update")
+	}
+	if err := s.PublishLightClientOptimisticUpdate(ctx, digest, optimisticUpdate); err != nil {
+		log.WithError(err).Error("Could not publish light client optimistic update")
+	}
+}
+
+func newLightClientSeenCache() *lru.Cache {
+	cache, err := lru.New(lightClientSeenCacheSize)
+	if err != nil {
+		panic(err) // only fails on a non-positive size, which lightClientSeenCacheSize never is
+	}
+	return cache
+}
@@ -0,0 +1,27 @@
+package p2p
+
+import (
+	"testing"
+)
+
+// TestLightClientUpdateSeenCache exercises the dedupe cache PublishLightClientFinalityUpdate
+// and PublishLightClientOptimisticUpdate key off of: a key added once is reported seen on
+// every later lookup, and distinct keys never collide.
+func TestLightClientUpdateSeenCache(t *testing.T) {
+	cache := newLightClientSeenCache()
+
+	key := lightClientUpdateKey{attestedSlot: 10, finalizedSlot: 8, participation: 512}
+	if _, ok := cache.Get(key); ok {
+		t.Fatal("expected a fresh cache to not have seen the key yet")
+	}
+
+	cache.Add(key, true)
+	if _, ok := cache.Get(key); !ok {
+		t.Fatal("expected the cache to report the key as seen after Add")
+	}
+
+	other := lightClientUpdateKey{attestedSlot: 11, finalizedSlot: 8, participation: 512}
+	if _, ok := cache.Get(other); ok {
+		t.Fatal("expected a distinct key to not be reported as seen")
+	}
+}
@@ -0,0 +1,48 @@
+package portal
+
+import (
+	"net"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	lightclient "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/light-client"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
+)
+
+// beaconProtocolID is the sub-protocol identifier Portal beacon network nodes register
+// with the discv5 TALKREQ/TALKRESP transport, per the Portal wire-protocol spec.
+const beaconProtocolID = "portal-beacon"
+
+// Discv5Listener is the subset of *discover.UDPv5 Service needs in order to answer Portal
+// beacon subnetwork requests: registering a handler for the TALKREQ protocol named by
+// beaconProtocolID. It is declared as an interface, rather than depending on go-ethereum's
+// concrete discv5 type directly, so this package doesn't dictate how the listener shared
+// with the rest of Prysm's p2p stack was constructed.
+type Discv5Listener interface {
+	RegisterTalkHandler(protocol string, handler func(id enode.ID, addr *net.UDPAddr, request []byte) []byte)
+}
+
+// Config bundles the dependencies the Portal beacon subnetwork Service needs to seed
+// light-client data to Portal Network peers, reusing the same lookups the REST light
+// client handlers already have.
+type Config struct {
+	// Enabled runs the Portal beacon subnetwork bridge alongside the beacon node.
+	Enabled bool
+
+	// Listener is the discv5 UDPv5 node shared with the rest of Prysm's p2p stack, so
+	// Portal peers see the same node identity and ENR the gossip network advertises.
+	// Start registers beaconProtocolID's TALKREQ handler against it; required whenever
+	// Enabled is set.
+	Listener Discv5Listener
+
+	BeaconDB         db.ReadOnlyDatabase
+	Stater           lookup.Stater
+	Blocker          lookup.Blocker
+	HeadFetcher      blockchain.HeadFetcher
+	ChainInfoFetcher blockchain.ChainInfoFetcher
+
+	// LightClient exposes the same bootstrap/finality/optimistic-update producers used
+	// by the beacon-APIs handlers, so Portal content lookups stay in lock-step with them.
+	LightClient *lightclient.Server
+}
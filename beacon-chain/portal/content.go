@@ -0,0 +1,75 @@
+package portal
+
+import (
+	"crypto/sha256"
+
+	"github.com/pkg/errors"
+)
+
+// ContentType identifies which beacon wire content a Portal Network message carries,
+// per the beacon light-client content types defined by the Portal beacon subnetwork spec.
+type ContentType byte
+
+const (
+	// ContentTypeLightClientBootstrap serves interfaces.LightClientBootstrap keyed by block root.
+	ContentTypeLightClientBootstrap ContentType = iota
+	// ContentTypeLightClientUpdatesByRange serves a contiguous run of updates keyed by
+	// (start_period, count).
+	ContentTypeLightClientUpdatesByRange
+	// ContentTypeLightClientFinalityUpdate serves the latest finality update.
+	ContentTypeLightClientFinalityUpdate
+	// ContentTypeLightClientOptimisticUpdate serves the latest optimistic update.
+	ContentTypeLightClientOptimisticUpdate
+	// ContentTypeHistoricalSummaries serves the beacon state's historical_summaries field.
+	ContentTypeHistoricalSummaries
+)
+
+// ContentKey identifies a single piece of beacon content on the Portal Network, matching
+// the wire format expected by FindContent/Offer: a one-byte selector followed by an
+// SSZ-encoded, content-type-specific key.
+type ContentKey struct {
+	Type    ContentType
+	Payload []byte
+}
+
+// Encode returns the wire representation of the content key: selector || payload.
+func (k ContentKey) Encode() []byte {
+	out := make([]byte, 0, 1+len(k.Payload))
+	out = append(out, byte(k.Type))
+	return append(out, k.Payload...)
+}
+
+// DecodeContentKey parses a wire content key produced by Encode.
+func DecodeContentKey(raw []byte) (ContentKey, error) {
+	if len(raw) == 0 {
+		return ContentKey{}, errors.New("empty content key")
+	}
+	return ContentKey{Type: ContentType(raw[0]), Payload: raw[1:]}, nil
+}
+
+// ContentID derives the 32-byte content ID used for DHT distance calculations, defined by
+// the Portal wire protocol as sha256(content_key).
+func ContentID(key ContentKey) [32]byte {
+	return sha256.Sum256(key.Encode())
+}
+
+// LightClientBootstrapKey builds a ContentTypeLightClientBootstrap content key for the
+// block root a light client wants to bootstrap from.
+func LightClientBootstrapKey(blockRoot [32]byte) ContentKey {
+	return ContentKey{Type: ContentTypeLightClientBootstrap, Payload: blockRoot[:]}
+}
+
+// LightClientUpdatesByRangeKey builds a ContentTypeLightClientUpdatesByRange content key,
+// SSZ-encoding start_period and count as consecutive little-endian uint64s.
+func LightClientUpdatesByRangeKey(startPeriod, count uint64) ContentKey {
+	payload := make([]byte, 16)
+	putUint64(payload[0:8], startPeriod)
+	putUint64(payload[8:16], count)
+	return ContentKey{Type: ContentTypeLightClientUpdatesByRange, Payload: payload}
+}
+
+func putUint64(dst []byte, v uint64) {
+	for i := 0; i < 8; i++ {
+		dst[i] = byte(v >> (8 * i))
+	}
+}
@@ -0,0 +1,62 @@
+package portal
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+)
+
+func TestContentKey_EncodeDecodeRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		key  ContentKey
+	}{
+		{"bootstrap", LightClientBootstrapKey([32]byte{0xaa, 0xbb})},
+		{"updates by range", LightClientUpdatesByRangeKey(7, 3)},
+		{"empty payload", ContentKey{Type: ContentTypeLightClientFinalityUpdate}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := DecodeContentKey(tt.key.Encode())
+			if err != nil {
+				t.Fatalf("DecodeContentKey returned an error: %v", err)
+			}
+			if decoded.Type != tt.key.Type {
+				t.Errorf("decoded type = %d, want %d", decoded.Type, tt.key.Type)
+			}
+			if !bytes.Equal(decoded.Payload, tt.key.Payload) {
+				t.Errorf("decoded payload = %x, want %x", decoded.Payload, tt.key.Payload)
+			}
+		})
+	}
+}
+
+func TestDecodeContentKey_RejectsEmpty(t *testing.T) {
+	if _, err := DecodeContentKey(nil); err == nil {
+		t.Fatal("expected an error decoding an empty content key")
+	}
+}
+
+func TestContentID_MatchesSha256OfEncodedKey(t *testing.T) {
+	key := LightClientBootstrapKey([32]byte{0x01, 0x02, 0x03})
+	want := sha256.Sum256(key.Encode())
+	if got := ContentID(key); got != want {
+		t.Errorf("ContentID() = %x, want %x", got, want)
+	}
+}
+
+func TestContentID_DifferentKeysDifferentIDs(t *testing.T) {
+	a := ContentID(LightClientBootstrapKey([32]byte{0x01}))
+	b := ContentID(LightClientBootstrapKey([32]byte{0x02}))
+	if a == b {
+		t.Error("expected distinct content keys to produce distinct content IDs")
+	}
+}
+
+func TestLightClientUpdatesByRangeKey_EncodesLittleEndian(t *testing.T) {
+	key := LightClientUpdatesByRangeKey(1, 2)
+	want := []byte{1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0}
+	if !bytes.Equal(key.Payload, want) {
+		t.Errorf("payload = %x, want %x", key.Payload, want)
+	}
+}
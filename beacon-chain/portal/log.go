@@ -0,0 +1,5 @@
+package portal
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "portal")
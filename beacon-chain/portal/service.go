@@ -0,0 +1,184 @@
+package portal
+
+import (
+	"context"
+	"net"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/wealdtech/go-bytesutil"
+)
+
+// Service bridges Prysm's existing light-client producers to the Portal Network beacon
+// wire protocol, letting a Prysm node seed LightClientBootstrap, LightClientUpdatesByRange,
+// LightClientFinalityUpdate, LightClientOptimisticUpdate and HistoricalSummaries content
+// to Portal peers over discv5 without running a second process.
+type Service struct {
+	cfg    *Config
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewService returns an uninitialized Portal beacon subnetwork service. Call Start to
+// register its TALKREQ handler on the configured discv5 listener (Config.Listener) and
+// begin answering content key requests.
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	if cfg == nil {
+		return nil, errors.New("nil config")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{cfg: cfg, ctx: ctx, cancel: cancel}, nil
+}
+
+// Start registers the beacon content handler on the discv5 TALKREQ/TALKRESP transport.
+// It is a no-op unless Config.Enabled is set, mirroring how other optional beacon-chain
+// subsystems (e.g. slasher) gate themselves on their own feature flag.
+func (s *Service) Start() {
+	if !s.cfg.Enabled {
+		return
+	}
+	if s.cfg.Listener == nil {
+		log.Error("Portal Network beacon subnetwork is enabled but no discv5 listener was configured")
+		return
+	}
+	log.WithField("protocol", beaconProtocolID).Info("Starting Portal Network beacon subnetwork bridge")
+	s.cfg.Listener.RegisterTalkHandler(beaconProtocolID, s.handleTalkRequest)
+}
+
+// handleTalkRequest adapts HandleTalkRequest to the discv5 TALKREQ handler signature
+// Discv5Listener.RegisterTalkHandler expects, discarding the requesting peer's id and
+// address since Portal content lookups don't depend on who's asking.
+func (s *Service) handleTalkRequest(_ enode.ID, _ *net.UDPAddr, request []byte) []byte {
+	return s.HandleTalkRequest(request)
+}
+
+// Stop tears down the service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status implements the shared beacon-chain Service interface.
+func (s *Service) Status() error {
+	return nil
+}
+
+// HandleTalkRequest answers a single discv5 TALKREQ addressed to beaconProtocolID,
+// decoding a Portal content key and looking it up via the same paths the beacon-APIs
+// light client handlers use.
+func (s *Service) HandleTalkRequest(rawKey []byte) []byte {
+	key, err := DecodeContentKey(rawKey)
+	if err != nil {
+		log.WithError(err).Debug("Could not decode Portal content key")
+		return nil
+	}
+
+	ctx, span := trace.StartSpan(s.ctx, "portal.HandleTalkRequest")
+	defer span.End()
+
+	content, err := s.lookupContent(ctx, key)
+	if err != nil {
+		log.WithError(err).WithField("contentType", key.Type).Debug("Could not serve Portal content request")
+		return nil
+	}
+	return content
+}
+
+// lookupContent resolves a decoded content key to its SSZ-encoded payload.
+func (s *Service) lookupContent(ctx context.Context, key ContentKey) ([]byte, error) {
+	switch key.Type {
+	case ContentTypeLightClientBootstrap:
+		blockRoot := bytesutil.ToBytes32(key.Payload)
+		bootstrap, err := s.cfg.LightClient.Bootstrap(ctx, blockRoot)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not build light client bootstrap")
+		}
+		return bootstrap.MarshalSSZ()
+
+	case ContentTypeLightClientUpdatesByRange:
+		if len(key.Payload) != 16 {
+			return nil, errors.New("malformed updates-by-range content key")
+		}
+		startPeriod := getUint64(key.Payload[0:8])
+		count := getUint64(key.Payload[8:16])
+		return s.updatesByRangeSSZ(ctx, startPeriod, count)
+
+	case ContentTypeLightClientFinalityUpdate:
+		_, _, update, err := s.cfg.LightClient.FinalityUpdate(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not build light client finality update")
+		}
+		return update.MarshalSSZ()
+
+	case ContentTypeLightClientOptimisticUpdate:
+		_, _, update, err := s.cfg.LightClient.OptimisticUpdate(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not build light client optimistic update")
+		}
+		return update.MarshalSSZ()
+
+	case ContentTypeHistoricalSummaries:
+		return s.historicalSummariesSSZ(ctx)
+
+	default:
+		return nil, errors.Errorf("unsupported content type %d", key.Type)
+	}
+}
+
+// updatesByRangeSSZ pulls the requested period range from BeaconDB.LightClientUpdates
+// and frames each element the same way GetLightClientUpdatesByRange does over HTTP, so
+// Portal consumers can share a single decoder with beacon-API SSZ clients. count is
+// rejected/clamped the same way the HTTP handler does it, since it arrives here straight
+// off an unauthenticated discv5 TALKREQ content key.
+func (s *Service) updatesByRangeSSZ(ctx context.Context, startPeriod, count uint64) ([]byte, error) {
+	if count == 0 {
+		return nil, errors.New("count must be greater than 0")
+	}
+	if count > params.BeaconConfig().MaxRequestLightClientUpdates {
+		count = params.BeaconConfig().MaxRequestLightClientUpdates
+	}
+
+	endPeriod := startPeriod + count - 1
+	updatesMap, err := s.cfg.BeaconDB.LightClientUpdates(ctx, startPeriod, endPeriod)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get light client updates from DB")
+	}
+
+	var buf []byte
+	for i := startPeriod; i <= endPeriod; i++ {
+		update, ok := updatesMap[i]
+		if !ok {
+			break
+		}
+		encoded, err := s.cfg.LightClient.EncodeUpdateWithForkDigest(update)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not encode update")
+		}
+		buf = append(buf, encoded...)
+	}
+	return buf, nil
+}
+
+// historicalSummariesSSZ reads historical_summaries off the current head state, the
+// same field the light-client bootstrap/update proofs are derived against.
+func (s *Service) historicalSummariesSSZ(ctx context.Context) ([]byte, error) {
+	st, err := s.cfg.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head state")
+	}
+	summaries, err := st.HistoricalSummaries()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get historical summaries")
+	}
+	return summaries.MarshalSSZ()
+}
+
+func getUint64(src []byte) uint64 {
+	var v uint64
+	for i := 7; i >= 0; i-- {
+		v = v<<8 | uint64(src[i])
+	}
+	return v
+}
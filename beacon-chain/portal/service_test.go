@@ -0,0 +1,13 @@
+package portal
+
+import (
+	"context"
+	"testing"
+)
+
+func TestUpdatesByRangeSSZ_RejectsZeroCount(t *testing.T) {
+	s := &Service{ctx: context.Background(), cfg: &Config{}}
+	if _, err := s.updatesByRangeSSZ(context.Background(), 0, 0); err == nil {
+		t.Fatal("expected an error for count == 0, got nil")
+	}
+}
@@ -0,0 +1,152 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	lightclient "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/light-client"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+)
+
+// Topic names recognized by the "topics" query parameter of StreamEvents, mirroring the
+// beacon-APIs eventstream schema. light_client_finality_update, light_client_optimistic_update
+// and light_client_update are defined alongside their producer in the light-client package
+// (lightclient.FinalityUpdateTopic, lightclient.OptimisticUpdateTopic, lightclient.UpdateTopic)
+// rather than redeclared here, since that's where the payloads they carry are built.
+const (
+	HeadTopic                = "head"
+	FinalizedCheckpointTopic = "finalized_checkpoint"
+)
+
+// Server implements the shared GET /eth/v1/events SSE broker: the head and
+// finalized_checkpoint topics and the light client ones are fanned out to requesting
+// clients through this single handler rather than each producer running its own stream.
+type Server struct {
+	StateNotifier statefeed.Notifier
+
+	// LightClient is the rpc light client Server whose LightClientFeed carries
+	// light_client_finality_update, light_client_optimistic_update and light_client_update
+	// events. It is optional so a node built with the light client feature disabled (and
+	// therefore no feed) can still serve every other topic.
+	LightClient *lightclient.Server
+}
+
+// StreamEvents implements the GET /eth/v1/events handler, subscribing to the state feed
+// and, when configured, the light client feed, and writing whichever of those events the
+// requesting client asked for via the "topics" query parameter onto an SSE stream.
+func (s *Server) StreamEvents(w http.ResponseWriter, req *http.Request) {
+	ctx, span := trace.StartSpan(req.Context(), "events.StreamEvents")
+	defer span.End()
+
+	topics := req.URL.Query()["topics"]
+	if len(topics) == 0 {
+		httputil.HandleError(w, "No topics specified to subscribe to", http.StatusBadRequest)
+		return
+	}
+	requested := make(map[string]bool, len(topics))
+	for _, t := range topics {
+		requested[t] = true
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		httputil.HandleError(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Cache-Control", "no-cache")
+	flusher.Flush()
+
+	stateChan := make(chan *statefeed.Event, 1)
+	stateSub := s.StateNotifier.StateFeed().Subscribe(stateChan)
+	defer stateSub.Unsubscribe()
+
+	var lightClientChan chan *lightclient.Event
+	if s.LightClient != nil && s.LightClient.LightClientFeed != nil {
+		lightClientChan = make(chan *lightclient.Event, 1)
+		lightClientSub := s.LightClient.LightClientFeed.Subscribe(lightClientChan)
+		defer lightClientSub.Unsubscribe()
+
+		// A client that subscribes mid-period still wants the current finality/optimistic
+		// update, not just the next one produced after it connects. Replay whatever is
+		// already cached before entering the select loop below.
+		s.writeLightClientEvent(w, flusher, requested, s.LightClient.LatestFinalityUpdate())
+		s.writeLightClientEvent(w, flusher, requested, s.LightClient.LatestOptimisticUpdate())
+	}
+
+	for {
+		select {
+		case evt := <-stateChan:
+			s.writeStateEvent(w, flusher, requested, evt)
+		case evt := <-lightClientChan:
+			s.writeLightClientEvent(w, flusher, requested, evt)
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Could not subscribe to state feed")
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// writeStateEvent forwards a state feed event onto the SSE stream under its beacon-API
+// topic name, if the client requested it. Only BlockProcessed (head) and
+// FinalizedCheckpoint (finalized_checkpoint) are mapped to topics; other state feed event
+// types are ignored.
+func (s *Server) writeStateEvent(w http.ResponseWriter, flusher http.Flusher, requested map[string]bool, evt *statefeed.Event) {
+	var topic string
+	switch evt.Type {
+	case statefeed.BlockProcessed:
+		topic = HeadTopic
+	case statefeed.FinalizedCheckpoint:
+		topic = FinalizedCheckpointTopic
+	default:
+		return
+	}
+	if !requested[topic] {
+		return
+	}
+	raw, err := json.Marshal(evt.Data)
+	if err != nil {
+		log.WithError(err).WithField("topic", topic).Error("Could not marshal event data")
+		return
+	}
+	s.writeSSE(w, flusher, topic, raw)
+}
+
+// writeLightClientEvent forwards a light client feed event onto the SSE stream under the
+// topic it was published on, if the client requested it.
+func (s *Server) writeLightClientEvent(w http.ResponseWriter, flusher http.Flusher, requested map[string]bool, evt *lightclient.Event) {
+	if evt == nil || !requested[evt.Topic] {
+		return
+	}
+	if evt.EventID != "" {
+		s.writeSSEWithID(w, flusher, evt.Topic, evt.EventID, evt.Data)
+		return
+	}
+	s.writeSSE(w, flusher, evt.Topic, evt.Data)
+}
+
+// writeSSE writes a single "event: <topic>\ndata: <data>\n\n" frame and flushes it to the
+// client immediately, the same framing every topic on this stream uses.
+func (s *Server) writeSSE(w http.ResponseWriter, flusher http.Flusher, topic string, data json.RawMessage) {
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", topic, data); err != nil {
+		log.WithError(err).WithField("topic", topic).Error("Could not write event to stream")
+		return
+	}
+	flusher.Flush()
+}
+
+// writeSSEWithID is writeSSE plus an "id:" field, used by topics (currently only the
+// light client ones) whose events carry an Event-Id clients can resume from.
+func (s *Server) writeSSEWithID(w http.ResponseWriter, flusher http.Flusher, topic, eventID string, data json.RawMessage) {
+	if _, err := fmt.Fprintf(w, "event: %s\nid: %s\ndata: %s\n\n", topic, eventID, data); err != nil {
+		log.WithError(err).WithField("topic", topic).Error("Could not write event to stream")
+		return
+	}
+	flusher.Flush()
+}
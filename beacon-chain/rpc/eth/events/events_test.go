@@ -0,0 +1,192 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/async/event"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	lightclient "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/light-client"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+func TestWriteStateEvent_OnlyWritesRequestedTopic(t *testing.T) {
+	s := &Server{}
+	w := httptest.NewRecorder()
+	evt := &statefeed.Event{Type: statefeed.BlockProcessed, Data: struct{}{}}
+
+	s.writeStateEvent(w, w, map[string]bool{FinalizedCheckpointTopic: true}, evt)
+	if w.Body.Len() != 0 {
+		t.Errorf("expected nothing written for an unrequested topic, got %q", w.Body.String())
+	}
+
+	s.writeStateEvent(w, w, map[string]bool{HeadTopic: true}, evt)
+	if !strings.Contains(w.Body.String(), "event: "+HeadTopic) {
+		t.Errorf("expected a %q event frame, got %q", HeadTopic, w.Body.String())
+	}
+}
+
+func TestWriteStateEvent_IgnoresUnrecognizedEventType(t *testing.T) {
+	s := &Server{}
+	w := httptest.NewRecorder()
+	evt := &statefeed.Event{Type: statefeed.EventType(9999), Data: struct{}{}}
+
+	s.writeStateEvent(w, w, map[string]bool{HeadTopic: true, FinalizedCheckpointTopic: true}, evt)
+	if w.Body.Len() != 0 {
+		t.Errorf("expected nothing written for an event type this broker doesn't map to a topic, got %q", w.Body.String())
+	}
+}
+
+func TestWriteLightClientEvent_OnlyWritesRequestedTopic(t *testing.T) {
+	s := &Server{}
+	w := httptest.NewRecorder()
+	evt := &lightclient.Event{Topic: lightclient.FinalityUpdateTopic, EventID: "0xabc", Data: json.RawMessage(`{"foo":"bar"}`)}
+
+	s.writeLightClientEvent(w, w, map[string]bool{lightclient.OptimisticUpdateTopic: true}, evt)
+	if w.Body.Len() != 0 {
+		t.Errorf("expected nothing written for an unrequested topic, got %q", w.Body.String())
+	}
+
+	s.writeLightClientEvent(w, w, map[string]bool{lightclient.FinalityUpdateTopic: true}, evt)
+	body := w.Body.String()
+	if !strings.Contains(body, "event: "+lightclient.FinalityUpdateTopic) || !strings.Contains(body, "id: 0xabc") {
+		t.Errorf("expected a finality update frame carrying its Event-Id, got %q", body)
+	}
+}
+
+func TestWriteLightClientEvent_NilEventIsNoop(t *testing.T) {
+	s := &Server{}
+	w := httptest.NewRecorder()
+	s.writeLightClientEvent(w, w, map[string]bool{lightclient.FinalityUpdateTopic: true}, nil)
+	if w.Body.Len() != 0 {
+		t.Errorf("expected nothing written for a nil event, got %q", w.Body.String())
+	}
+}
+
+func TestWriteSSE_OmitsIDField(t *testing.T) {
+	s := &Server{}
+	w := httptest.NewRecorder()
+	s.writeSSE(w, w, HeadTopic, json.RawMessage(`{}`))
+	if strings.Contains(w.Body.String(), "id:") {
+		t.Errorf("expected no id: field from writeSSE, got %q", w.Body.String())
+	}
+}
+
+// eventsTestHeader is the minimal interfaces.LightClientHeader implementation the
+// StreamEvents replay test needs: only Beacon().Slot is ever read.
+type eventsTestHeader struct {
+	interfaces.LightClientHeader
+	slot primitives.Slot
+}
+
+func (h *eventsTestHeader) Beacon() *ethpb.BeaconBlockHeader {
+	return &ethpb.BeaconBlockHeader{Slot: h.slot}
+}
+
+type eventsTestFinalityUpdate struct {
+	interfaces.LightClientFinalityUpdate
+}
+
+func (u *eventsTestFinalityUpdate) AttestedHeader() interfaces.LightClientHeader {
+	return &eventsTestHeader{slot: 1}
+}
+
+func (u *eventsTestFinalityUpdate) FinalizedHeader() interfaces.LightClientHeader {
+	return &eventsTestHeader{slot: 1}
+}
+
+func (u *eventsTestFinalityUpdate) SyncAggregate() *ethpb.SyncAggregate {
+	bits := bitfield.NewBitvector512()
+	for i := uint64(0); i < 400; i++ {
+		bits.SetBitAt(i, true)
+	}
+	return &ethpb.SyncAggregate{SyncCommitteeBits: bits}
+}
+
+func (u *eventsTestFinalityUpdate) SignatureSlot() primitives.Slot { return 2 }
+
+type eventsTestOptimisticUpdate struct {
+	interfaces.LightClientOptimisticUpdate
+}
+
+func (u *eventsTestOptimisticUpdate) AttestedHeader() interfaces.LightClientHeader {
+	return &eventsTestHeader{slot: 1}
+}
+
+// eventsTestStateNotifier is a statefeed.Notifier backed by a feed nothing ever sends on,
+// so TestStreamEvents_ReplaysCachedLightClientUpdatesBeforeNewEvents only observes the
+// replay writes, not anything from the state feed's own select case.
+type eventsTestStateNotifier struct {
+	feed event.Feed
+}
+
+func (n *eventsTestStateNotifier) StateFeed() *event.Feed {
+	return &n.feed
+}
+
+// TestStreamEvents_ReplaysCachedLightClientUpdatesBeforeNewEvents reproduces the review
+// finding that StreamEvents' replay-on-connect behavior - writing whatever is already
+// cached before entering its event loop - had no test coverage. A client that subscribes
+// mid-period must see the cached finality/optimistic updates even if no new one is ever
+// produced while it's connected.
+func TestStreamEvents_ReplaysCachedLightClientUpdatesBeforeNewEvents(t *testing.T) {
+	lc := &lightclient.Server{}
+	if _, err := lc.IngestFinalityUpdate(
+		&structs.LightClientFinalityUpdateResponse{}, [32]byte{0x01}, &eventsTestFinalityUpdate{},
+	); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lc.IngestOptimisticUpdate(
+		&structs.LightClientOptimisticUpdateResponse{}, [32]byte{0x02}, &eventsTestOptimisticUpdate{},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{
+		StateNotifier: &eventsTestStateNotifier{},
+		LightClient:   lc,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/?topics="+lightclient.FinalityUpdateTopic+"&topics="+lightclient.OptimisticUpdateTopic, nil)
+	req = req.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		s.StreamEvents(w, req)
+		close(done)
+	}()
+
+	// Give the replay writes - which happen before the select loop ever runs - a moment to
+	// land, then stop the stream without ever sending a new event on either feed.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("StreamEvents did not return after its context was canceled")
+	}
+
+	body := w.Body.String()
+	finalityIdx := strings.Index(body, "event: "+lightclient.FinalityUpdateTopic)
+	optimisticIdx := strings.Index(body, "event: "+lightclient.OptimisticUpdateTopic)
+	if finalityIdx == -1 {
+		t.Fatalf("expected the cached finality update to be replayed on connect, got %q", body)
+	}
+	if optimisticIdx == -1 {
+		t.Fatalf("expected the cached optimistic update to be replayed on connect, got %q", body)
+	}
+	if finalityIdx > optimisticIdx {
+		t.Errorf("expected the finality update to be replayed before the optimistic update, as StreamEvents writes them in that order")
+	}
+}
@@ -0,0 +1,178 @@
+package lightclient
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	lightclient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+)
+
+const (
+	// FinalityUpdateTopic is the SSE topic light clients subscribe to in order to
+	// receive light_client_finality_update events, mirroring the beacon-APIs event schema.
+	FinalityUpdateTopic = "light_client_finality_update"
+	// OptimisticUpdateTopic is the SSE topic light clients subscribe to in order to
+	// receive light_client_optimistic_update events.
+	OptimisticUpdateTopic = "light_client_optimistic_update"
+	// UpdateTopic is emitted whenever a new best update for a sync committee period is
+	// persisted to BeaconDB.LightClientUpdates, announcing period boundaries to subscribers.
+	UpdateTopic = "light_client_update"
+
+	// ringBufferSize bounds how many recent updates are retained per topic. Only the newest
+	// entry is read back (by latest).
+	ringBufferSize = 4
+)
+
+// Event is the payload sent over the LightClientFeed and rendered onto the SSE stream by
+// the shared events broker (beacon-chain/rpc/eth/events). EventID is the 0x-prefixed
+// attested header root and is written as the SSE "id:" field. The broker does not read a
+// Last-Event-ID header: on connect it replays only the latest cached event per topic. It is
+// exported so that broker, which lives in a different package and subscribes directly to
+// LightClientFeed, can receive these values.
+type Event struct {
+	Topic   string
+	EventID string
+	Data    json.RawMessage
+}
+
+// lightClientUpdateCache is a small ring buffer of the most recently produced updates
+// for a single topic, so a client that subscribes to the SSE stream gets the current
+// state immediately instead of waiting for the next block. It also remembers the raw
+// consensus update behind the latest entry so a newly produced or gossiped update can be
+// ranked against it with lightclient.IsBetterUpdate before being allowed to replace it.
+type lightClientUpdateCache struct {
+	mu      sync.RWMutex
+	entries []*Event
+	raw     any
+}
+
+func newLightClientUpdateCache() *lightClientUpdateCache {
+	return &lightClientUpdateCache{entries: make([]*Event, 0, ringBufferSize)}
+}
+
+// push adds the newest event to the buffer, evicting the oldest entry once full, and
+// records raw as the update behind it.
+func (c *lightClientUpdateCache) push(evt *Event, raw any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = append(c.entries, evt)
+	if len(c.entries) > ringBufferSize {
+		c.entries = c.entries[len(c.entries)-ringBufferSize:]
+	}
+	c.raw = raw
+}
+
+// latest returns the most recently pushed event, or nil if nothing has been produced yet.
+func (c *lightClientUpdateCache) latest() *Event {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.entries) == 0 {
+		return nil
+	}
+	return c.entries[len(c.entries)-1]
+}
+
+// latestRaw returns the consensus update behind the most recently pushed event, or nil if
+// nothing has been produced yet.
+func (c *lightClientUpdateCache) latestRaw() any {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.raw
+}
+
+// IngestFinalityUpdate records resp in the finality-update ring buffer and notifies
+// LightClientFeed subscribers, but only if lightclient.IsBetterUpdate ranks update strictly
+// better than whatever is currently cached. This lets the same entry point serve both locally-produced updates
+// (always fresh, derived from the current head) and updates received over gossip, which
+// may be stale relative to what this node already has. The returned bool reports whether
+// resp was actually cached and published.
+func (s *Server) IngestFinalityUpdate(resp *structs.LightClientFinalityUpdateResponse, attestedRoot [32]byte, update interfaces.LightClientFinalityUpdate) (bool, error) {
+	s.initCaches()
+	if existing, ok := s.finalityUpdateCache.latestRaw().(interfaces.LightClientFinalityUpdate); ok {
+		if !lightclient.IsBetterUpdate(update, existing) {
+			return false, nil
+		}
+	}
+
+	evt, err := s.newLightClientEvent(FinalityUpdateTopic, attestedRoot, resp)
+	if err != nil {
+		return false, err
+	}
+	s.finalityUpdateCache.push(evt, update)
+	if s.LightClientFeed != nil {
+		s.LightClientFeed.Send(evt)
+	}
+	return true, nil
+}
+
+// IngestOptimisticUpdate records resp in the optimistic-update ring buffer and notifies
+// LightClientFeed subscribers, but only if update's attested slot is newer than that of
+// whatever is currently cached. Optimistic updates carry no finalized header, so they
+// can't be ranked with lightclient.IsBetterUpdate; attested slot is the only ordering the
+// spec gives us. The returned bool reports whether resp was actually cached and published.
+func (s *Server) IngestOptimisticUpdate(resp *structs.LightClientOptimisticUpdateResponse, attestedRoot [32]byte, update interfaces.LightClientOptimisticUpdate) (bool, error) {
+	s.initCaches()
+	if existing, ok := s.optimisticUpdateCache.latestRaw().(interfaces.LightClientOptimisticUpdate); ok {
+		if update.AttestedHeader().Beacon().Slot <= existing.AttestedHeader().Beacon().Slot {
+			return false, nil
+		}
+	}
+
+	evt, err := s.newLightClientEvent(OptimisticUpdateTopic, attestedRoot, resp)
+	if err != nil {
+		return false, err
+	}
+	s.optimisticUpdateCache.push(evt, update)
+	if s.LightClientFeed != nil {
+		s.LightClientFeed.Send(evt)
+	}
+	return true, nil
+}
+
+// LatestFinalityUpdate returns the most recently cached light_client_finality_update event,
+// or nil if none has been produced yet. StreamEvents calls this to give a freshly-subscribed
+// client the current state immediately instead of making it wait for the next block.
+func (s *Server) LatestFinalityUpdate() *Event {
+	s.initCaches()
+	return s.finalityUpdateCache.latest()
+}
+
+// LatestOptimisticUpdate is the optimistic-update counterpart of LatestFinalityUpdate.
+func (s *Server) LatestOptimisticUpdate() *Event {
+	s.initCaches()
+	return s.optimisticUpdateCache.latest()
+}
+
+// PublishUpdate is called by SaveUpdate once a new best update has been stored in
+// BeaconDB.LightClientUpdates for a sync committee period, announcing the period boundary
+// on the light_client_update topic.
+func (s *Server) PublishUpdate(period uint64, resp *structs.LightClientUpdateResponse, attestedRoot [32]byte) error {
+	evt, err := s.newLightClientEvent(UpdateTopic, attestedRoot, resp)
+	if err != nil {
+		return err
+	}
+	if s.LightClientFeed != nil {
+		s.LightClientFeed.Send(evt)
+	}
+	return nil
+}
+
+func (s *Server) newLightClientEvent(topic string, attestedRoot [32]byte, data any) (*Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal light client event")
+	}
+	return &Event{
+		Topic:   topic,
+		EventID: eventIDFromRoot(attestedRoot),
+		Data:    raw,
+	}, nil
+}
+
+func eventIDFromRoot(root [32]byte) string {
+	return "0x" + hex.EncodeToString(root[:])
+}
@@ -0,0 +1,166 @@
+package lightclient
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+// eventsTestHeader is the minimal interfaces.LightClientHeader implementation this file's
+// tests need: only Beacon().Slot is ever read by IsBetterUpdate/the attested-slot gate.
+type eventsTestHeader struct {
+	interfaces.LightClientHeader
+	slot primitives.Slot
+}
+
+func (h *eventsTestHeader) Beacon() *ethpb.BeaconBlockHeader {
+	return &ethpb.BeaconBlockHeader{Slot: h.slot}
+}
+
+func eventsTestFullBits(n uint64) bitfield.Bitvector512 {
+	bits := bitfield.NewBitvector512()
+	for i := uint64(0); i < n; i++ {
+		bits.SetBitAt(i, true)
+	}
+	return bits
+}
+
+// eventsTestFinalityUpdate implements interfaces.LightClientFinalityUpdate with whatever
+// combination of slots and participation a test case needs to drive IsBetterUpdate.
+type eventsTestFinalityUpdate struct {
+	interfaces.LightClientFinalityUpdate
+	attestedSlot, finalizedSlot primitives.Slot
+	participants                uint64
+}
+
+func (u *eventsTestFinalityUpdate) AttestedHeader() interfaces.LightClientHeader {
+	return &eventsTestHeader{slot: u.attestedSlot}
+}
+
+func (u *eventsTestFinalityUpdate) FinalizedHeader() interfaces.LightClientHeader {
+	return &eventsTestHeader{slot: u.finalizedSlot}
+}
+
+func (u *eventsTestFinalityUpdate) SyncAggregate() *ethpb.SyncAggregate {
+	return &ethpb.SyncAggregate{SyncCommitteeBits: eventsTestFullBits(u.participants)}
+}
+
+func (u *eventsTestFinalityUpdate) SignatureSlot() primitives.Slot { return u.attestedSlot + 1 }
+
+// eventsTestOptimisticUpdate implements interfaces.LightClientOptimisticUpdate.
+type eventsTestOptimisticUpdate struct {
+	interfaces.LightClientOptimisticUpdate
+	attestedSlot primitives.Slot
+}
+
+func (u *eventsTestOptimisticUpdate) AttestedHeader() interfaces.LightClientHeader {
+	return &eventsTestHeader{slot: u.attestedSlot}
+}
+
+func TestIngestFinalityUpdate_RejectsWeakerThanCached(t *testing.T) {
+	s := &Server{}
+	stronger := &eventsTestFinalityUpdate{attestedSlot: 100, finalizedSlot: 100, participants: 400}
+	if stored, err := s.IngestFinalityUpdate(&structs.LightClientFinalityUpdateResponse{}, [32]byte{0x01}, stronger); err != nil || !stored {
+		t.Fatalf("expected the first update to be stored, got stored=%v err=%v", stored, err)
+	}
+
+	weaker := &eventsTestFinalityUpdate{attestedSlot: 99, finalizedSlot: 99, participants: 200}
+	stored, err := s.IngestFinalityUpdate(&structs.LightClientFinalityUpdateResponse{}, [32]byte{0x02}, weaker)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored {
+		t.Error("expected an update ranking worse than the cached one to be rejected")
+	}
+	if got := s.LatestFinalityUpdate(); got == nil || got.EventID != eventIDFromRoot([32]byte{0x01}) {
+		t.Error("expected the cache to still serve the first, stronger update")
+	}
+}
+
+func TestIngestFinalityUpdate_AcceptsStrongerThanCached(t *testing.T) {
+	s := &Server{}
+	weaker := &eventsTestFinalityUpdate{attestedSlot: 99, finalizedSlot: 99, participants: 200}
+	if stored, err := s.IngestFinalityUpdate(&structs.LightClientFinalityUpdateResponse{}, [32]byte{0x01}, weaker); err != nil || !stored {
+		t.Fatalf("expected the first update to be stored, got stored=%v err=%v", stored, err)
+	}
+
+	stronger := &eventsTestFinalityUpdate{attestedSlot: 100, finalizedSlot: 100, participants: 400}
+	stored, err := s.IngestFinalityUpdate(&structs.LightClientFinalityUpdateResponse{}, [32]byte{0x02}, stronger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stored {
+		t.Error("expected an update ranking better than the cached one to be accepted")
+	}
+	if got := s.LatestFinalityUpdate(); got == nil || got.EventID != eventIDFromRoot([32]byte{0x02}) {
+		t.Error("expected the cache to now serve the stronger update")
+	}
+}
+
+func TestIngestOptimisticUpdate_RejectsStaleSlot(t *testing.T) {
+	s := &Server{}
+	newer := &eventsTestOptimisticUpdate{attestedSlot: 100}
+	if stored, err := s.IngestOptimisticUpdate(&structs.LightClientOptimisticUpdateResponse{}, [32]byte{0x01}, newer); err != nil || !stored {
+		t.Fatalf("expected the first update to be stored, got stored=%v err=%v", stored, err)
+	}
+
+	older := &eventsTestOptimisticUpdate{attestedSlot: 99}
+	stored, err := s.IngestOptimisticUpdate(&structs.LightClientOptimisticUpdateResponse{}, [32]byte{0x02}, older)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored {
+		t.Error("expected an update with an older attested slot than the cached one to be rejected")
+	}
+}
+
+func TestIngestOptimisticUpdate_AcceptsNewerSlot(t *testing.T) {
+	s := &Server{}
+	older := &eventsTestOptimisticUpdate{attestedSlot: 99}
+	if stored, err := s.IngestOptimisticUpdate(&structs.LightClientOptimisticUpdateResponse{}, [32]byte{0x01}, older); err != nil || !stored {
+		t.Fatalf("expected the first update to be stored, got stored=%v err=%v", stored, err)
+	}
+
+	newer := &eventsTestOptimisticUpdate{attestedSlot: 100}
+	stored, err := s.IngestOptimisticUpdate(&structs.LightClientOptimisticUpdateResponse{}, [32]byte{0x02}, newer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stored {
+		t.Error("expected an update with a newer attested slot than the cached one to be accepted")
+	}
+}
+
+func TestLightClientUpdateCache_EvictsOldestBeyondRingBufferSize(t *testing.T) {
+	c := newLightClientUpdateCache()
+	for i := 0; i < ringBufferSize+2; i++ {
+		c.push(&Event{EventID: fmt.Sprintf("%d", i)}, i)
+	}
+	if len(c.entries) != ringBufferSize {
+		t.Fatalf("expected the ring buffer capped at %d entries, got %d", ringBufferSize, len(c.entries))
+	}
+	if c.entries[0].EventID != "2" {
+		t.Errorf("expected the two oldest entries to have been evicted, oldest remaining entry is %q", c.entries[0].EventID)
+	}
+	if got := c.latest(); got == nil || got.EventID != fmt.Sprintf("%d", ringBufferSize+1) {
+		t.Errorf("expected latest() to return the most recently pushed entry, got %v", got)
+	}
+	if got := c.latestRaw(); got != ringBufferSize+1 {
+		t.Errorf("latestRaw() = %v, want %d", got, ringBufferSize+1)
+	}
+}
+
+func TestLightClientUpdateCache_EmptyCacheReturnsNil(t *testing.T) {
+	c := newLightClientUpdateCache()
+	if got := c.latest(); got != nil {
+		t.Errorf("expected latest() on an empty cache to return nil, got %v", got)
+	}
+	if got := c.latestRaw(); got != nil {
+		t.Errorf("expected latestRaw() on an empty cache to return nil, got %v", got)
+	}
+}
@@ -40,19 +40,7 @@ func (s *Server) GetLightClientBootstrap(w http.ResponseWriter, req *http.Reques
 	}
 
 	blockRoot := bytesutil.ToBytes32(blockRootParam)
-	blk, err := s.Blocker.Block(ctx, blockRoot[:])
-	if !shared.WriteBlockFetchError(w, blk, err) {
-		return
-	}
-
-	// Get the state
-	state, err := s.Stater.StateBySlot(ctx, blk.Block().Slot())
-	if err != nil {
-		httputil.HandleError(w, "could not get state: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	bootstrap, err := lightclient.NewLightClientBootstrapFromBeaconState(ctx, s.ChainInfoFetcher.CurrentSlot(), state, blk)
+	bootstrap, err := s.Bootstrap(ctx, blockRoot)
 	if err != nil {
 		httputil.HandleError(w, "could not get light client bootstrap: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -80,6 +68,33 @@ func (s *Server) GetLightClientBootstrap(w http.ResponseWriter, req *http.Reques
 	}
 }
 
+// Bootstrap builds the light client bootstrap for blockRoot. It is the shared
+// implementation behind GetLightClientBootstrap, exported so other consumers of
+// the same producer (e.g. the Portal Network bridge) don't have to duplicate it.
+func (s *Server) Bootstrap(ctx context.Context, blockRoot [32]byte) (interfaces.LightClientBootstrap, error) {
+	blk, err := s.Blocker.Block(ctx, blockRoot[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get block")
+	}
+	if blk == nil {
+		return nil, errors.New("block does not exist")
+	}
+
+	state, err := s.Stater.StateBySlot(ctx, blk.Block().Slot())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get state")
+	}
+
+	bootstrap, err := lightclient.NewLightClientBootstrapFromBeaconState(ctx, s.ChainInfoFetcher.CurrentSlot(), state, blk)
+	if err != nil {
+		return nil, err
+	}
+	if err := lightclient.VerifyLightClientBootstrap(bootstrap); err != nil {
+		return nil, errors.Wrap(err, "produced an invalid light client bootstrap")
+	}
+	return bootstrap, nil
+}
+
 // GetLightClientUpdatesByRange - implements https://github.com/ethereum/beacon-APIs/blob/263f4ed6c263c967f13279c7a9f5629b51c5fc55/apis/beacon/light_client/updates.yaml
 func (s *Server) GetLightClientUpdatesByRange(w http.ResponseWriter, req *http.Request) {
 	if !features.Get().EnableLightClient {
@@ -119,6 +134,7 @@ func (s *Server) GetLightClientUpdatesByRange(w http.ResponseWriter, req *http.R
 	}
 
 	updates := make([]*structs.LightClientUpdateResponse, 0, len(updatesMap))
+	consensusUpdates := make([]sszMarshaler, 0, len(updatesMap))
 
 	for i := startPeriod; i <= endPeriod; i++ {
 		update, ok := updatesMap[i]
@@ -126,6 +142,7 @@ func (s *Server) GetLightClientUpdatesByRange(w http.ResponseWriter, req *http.R
 			// Only return the first contiguous range of updates
 			break
 		}
+		consensusUpdates = append(consensusUpdates, update)
 
 		updateJson, err := structs.LightClientUpdateFromConsensus(update)
 		if err != nil {
@@ -138,6 +155,20 @@ func (s *Server) GetLightClientUpdatesByRange(w http.ResponseWriter, req *http.R
 		}
 		updates = append(updates, updateResponse)
 	}
+
+	if len(consensusUpdates) > 0 {
+		w.Header().Set(api.VersionHeader, version.String(consensusUpdates[len(consensusUpdates)-1].Version()))
+	}
+
+	if httputil.RespondWithSsz(req) {
+		ssz, err := s.marshalUpdatesByRangeSSZ(consensusUpdates)
+		if err != nil {
+			httputil.HandleError(w, "could not marshal updates to SSZ: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		httputil.WriteSsz(w, ssz, "light_client_updates.ssz")
+		return
+	}
 	httputil.WriteJson(w, updates)
 }
 
@@ -151,57 +182,99 @@ func (s *Server) GetLightClientFinalityUpdate(w http.ResponseWriter, req *http.R
 	ctx, span := trace.StartSpan(req.Context(), "beacon.GetLightClientFinalityUpdate")
 	defer span.End()
 
-	// Finality update needs super majority of sync committee signatures
+	response, attestedRoot, update, err := s.FinalityUpdate(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get light client finality update: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := s.IngestFinalityUpdate(response, attestedRoot, update); err != nil {
+		log.WithError(err).Error("Could not publish light client finality update")
+	}
+
+	w.Header().Set(api.VersionHeader, response.Version)
+
+	if httputil.RespondWithSsz(req) {
+		updateSsz, err := update.MarshalSSZ()
+		if err != nil {
+			httputil.HandleError(w, "could not marshal finality update to SSZ: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		httputil.WriteSsz(w, updateSsz, "light_client_finality_update.ssz")
+		return
+	}
+	httputil.WriteJson(w, response)
+}
+
+// FinalityUpdate builds the current light client finality update along with the
+// attested header root it was keyed on. It backs GetLightClientFinalityUpdate and is
+// exported so the SSE cache, gossip publisher and Portal Network bridge can share it.
+func (s *Server) FinalityUpdate(ctx context.Context) (*structs.LightClientFinalityUpdateResponse, [32]byte, interfaces.LightClientFinalityUpdate, error) {
+	var zeroRoot [32]byte
+
+	// Finality update needs super majority of sync committee signatures.
 	minSyncCommitteeParticipants := float64(params.BeaconConfig().MinSyncCommitteeParticipants)
 	minSignatures := uint64(math.Ceil(minSyncCommitteeParticipants * 2 / 3))
 
 	block, err := s.suitableBlock(ctx, minSignatures)
-	if !shared.WriteBlockFetchError(w, block, err) {
-		return
+	if err != nil {
+		return nil, zeroRoot, nil, err
 	}
 
 	st, err := s.Stater.StateBySlot(ctx, block.Block().Slot())
 	if err != nil {
-		httputil.HandleError(w, "Could not get state: "+err.Error(), http.StatusInternalServerError)
-		return
+		return nil, zeroRoot, nil, errors.Wrap(err, "could not get state")
 	}
 
 	attestedRoot := block.Block().ParentRoot()
 	attestedBlock, err := s.Blocker.Block(ctx, attestedRoot[:])
-	if !shared.WriteBlockFetchError(w, block, errors.Wrap(err, "could not get attested block")) {
-		return
+	if err != nil {
+		return nil, zeroRoot, nil, errors.Wrap(err, "could not get attested block")
 	}
 	attestedSlot := attestedBlock.Block().Slot()
 	attestedState, err := s.Stater.StateBySlot(ctx, attestedSlot)
 	if err != nil {
-		httputil.HandleError(w, "Could not get attested state: "+err.Error(), http.StatusInternalServerError)
-		return
+		return nil, zeroRoot, nil, errors.Wrap(err, "could not get attested state")
 	}
 
-	var finalizedBlock interfaces.ReadOnlySignedBeaconBlock
 	finalizedCheckpoint := attestedState.FinalizedCheckpoint()
 	if finalizedCheckpoint == nil {
-		httputil.HandleError(w, "Attested state does not have a finalized checkpoint", http.StatusInternalServerError)
-		return
+		return nil, zeroRoot, nil, errors.New("attested state does not have a finalized checkpoint")
 	}
 	finalizedRoot := bytesutil.ToBytes32(finalizedCheckpoint.Root)
-	finalizedBlock, err = s.Blocker.Block(ctx, finalizedRoot[:])
-	if !shared.WriteBlockFetchError(w, block, errors.Wrap(err, "could not get finalized block")) {
-		return
+	finalizedBlock, err := s.Blocker.Block(ctx, finalizedRoot[:])
+	if err != nil {
+		return nil, zeroRoot, nil, errors.Wrap(err, "could not get finalized block")
 	}
 
 	update, err := newLightClientFinalityUpdateFromBeaconState(ctx, s.ChainInfoFetcher.CurrentSlot(), st, block, attestedState, attestedBlock, finalizedBlock)
 	if err != nil {
-		httputil.HandleError(w, "Could not get light client finality update: "+err.Error(), http.StatusInternalServerError)
-		return
+		return nil, zeroRoot, nil, errors.Wrap(err, "could not build light client finality update")
+	}
+
+	if err := s.verifyOutgoingFinalityUpdate(update, attestedState); err != nil {
+		return nil, zeroRoot, nil, errors.Wrap(err, "produced an invalid light client finality update")
+	}
+
+	// A finality update meets the same supermajority-signature bar the periodic
+	// LightClientUpdate needs, so every call here is also a chance to advance the best
+	// known update for its sync committee period. This is the only place that happens:
+	// gossiped finality/optimistic updates carry no next-sync-committee proof, so they
+	// can't be turned into a periodic LightClientUpdate and never reach SaveUpdate - they
+	// only ever feed the SSE cache via IngestFinalityUpdate/IngestOptimisticUpdate.
+	if periodicUpdate, err := newLightClientUpdateFromBeaconState(ctx, s.ChainInfoFetcher.CurrentSlot(), st, block, attestedState, attestedBlock, finalizedBlock); err != nil {
+		log.WithError(err).Debug("Could not build light client update for period ranking")
+	} else if err := s.verifyOutgoingUpdate(periodicUpdate, attestedState); err != nil {
+		log.WithError(err).Error("Produced an invalid light client update, not saving it")
+	} else if _, err := s.SaveUpdate(ctx, periodicUpdate); err != nil {
+		log.WithError(err).Error("Could not save light client update")
 	}
 
 	response := &structs.LightClientFinalityUpdateResponse{
 		Version: version.String(attestedState.Version()),
 		Data:    update,
 	}
-
-	httputil.WriteJson(w, response)
+	return response, attestedRoot, update, nil
 }
 
 // GetLightClientOptimisticUpdate - implements https://github.com/ethereum/beacon-APIs/blob/263f4ed6c263c967f13279c7a9f5629b51c5fc55/apis/beacon/light_client/optimistic_update.yaml
@@ -214,48 +287,97 @@ func (s *Server) GetLightClientOptimisticUpdate(w http.ResponseWriter, req *http
 	ctx, span := trace.StartSpan(req.Context(), "beacon.GetLightClientOptimisticUpdate")
 	defer span.End()
 
-	block, err := s.suitableBlock(ctx, params.BeaconConfig().MinSyncCommitteeParticipants)
-	if !shared.WriteBlockFetchError(w, block, err) {
+	response, attestedRoot, update, err := s.OptimisticUpdate(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get light client optimistic update: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := s.IngestOptimisticUpdate(response, attestedRoot, update); err != nil {
+		log.WithError(err).Error("Could not publish light client optimistic update")
+	}
+
+	w.Header().Set(api.VersionHeader, response.Version)
+
+	if httputil.RespondWithSsz(req) {
+		updateSsz, err := update.MarshalSSZ()
+		if err != nil {
+			httputil.HandleError(w, "could not marshal optimistic update to SSZ: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		httputil.WriteSsz(w, updateSsz, "light_client_optimistic_update.ssz")
 		return
 	}
+	httputil.WriteJson(w, response)
+}
+
+// OptimisticUpdate builds the current light client optimistic update along with the
+// attested header root it was keyed on. It backs GetLightClientOptimisticUpdate and is
+// exported so the SSE cache, gossip publisher and Portal Network bridge can share it.
+func (s *Server) OptimisticUpdate(ctx context.Context) (*structs.LightClientOptimisticUpdateResponse, [32]byte, interfaces.LightClientOptimisticUpdate, error) {
+	var zeroRoot [32]byte
+
+	block, err := s.suitableBlock(ctx, params.BeaconConfig().MinSyncCommitteeParticipants)
+	if err != nil {
+		return nil, zeroRoot, nil, err
+	}
 	st, err := s.Stater.StateBySlot(ctx, block.Block().Slot())
 	if err != nil {
-		httputil.HandleError(w, "could not get state: "+err.Error(), http.StatusInternalServerError)
-		return
+		return nil, zeroRoot, nil, errors.Wrap(err, "could not get state")
 	}
 	attestedRoot := block.Block().ParentRoot()
 	attestedBlock, err := s.Blocker.Block(ctx, attestedRoot[:])
 	if err != nil {
-		httputil.HandleError(w, "Could not get attested block: "+err.Error(), http.StatusInternalServerError)
-		return
+		return nil, zeroRoot, nil, errors.Wrap(err, "could not get attested block")
 	}
 	if attestedBlock == nil {
-		httputil.HandleError(w, "Attested block is nil", http.StatusInternalServerError)
-		return
+		return nil, zeroRoot, nil, errors.New("attested block is nil")
 	}
 	attestedSlot := attestedBlock.Block().Slot()
 	attestedState, err := s.Stater.StateBySlot(ctx, attestedSlot)
 	if err != nil {
-		httputil.HandleError(w, "Could not get attested state: "+err.Error(), http.StatusInternalServerError)
-		return
+		return nil, zeroRoot, nil, errors.Wrap(err, "could not get attested state")
 	}
 
 	update, err := newLightClientOptimisticUpdateFromBeaconState(ctx, s.ChainInfoFetcher.CurrentSlot(), st, block, attestedState, attestedBlock)
 	if err != nil {
-		httputil.HandleError(w, "Could not get light client optimistic update: "+err.Error(), http.StatusInternalServerError)
-		return
+		return nil, zeroRoot, nil, errors.Wrap(err, "could not build light client optimistic update")
+	}
+
+	if err := s.verifyOutgoingOptimisticUpdate(update, attestedState); err != nil {
+		return nil, zeroRoot, nil, errors.Wrap(err, "produced an invalid light client optimistic update")
 	}
 
 	response := &structs.LightClientOptimisticUpdateResponse{
 		Version: version.String(attestedState.Version()),
 		Data:    update,
 	}
-
-	httputil.WriteJson(w, response)
+	return response, attestedRoot, update, nil
 }
 
-// suitableBlock returns the latest block that satisfies all criteria required for creating a new update
+// suitableBlock returns the latest block that satisfies all criteria required for creating
+// a new update, preferring the incrementally-maintained suitableBlockCache over the O(n)
+// ancestor walk below. The cache only resolves a tier once it has seen at least one state
+// feed event, so the walk remains as a fallback for a freshly started node (or a Server
+// built without a StateNotifier, e.g. in tests).
 func (s *Server) suitableBlock(ctx context.Context, minSignaturesRequired uint64) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	s.initSuitableBlockCache()
+
+	tier := suitableBlockCacheTierForMinSignatures(minSignaturesRequired)
+
+	if cached, headSlot := s.sbCache.get(tier); cached != nil {
+		suitableBlockCacheCount.WithLabelValues(tier, "hit").Inc()
+		suitableBlockLagSlots.WithLabelValues(tier).Set(float64(headSlot - cached.block.Block().Slot()))
+		return cached.block, nil
+	}
+	suitableBlockCacheCount.WithLabelValues(tier, "miss").Inc()
+
+	return s.suitableBlockUncached(ctx, minSignaturesRequired)
+}
+
+// suitableBlockUncached is the O(n) ancestor walk suitableBlock falls back to before the
+// cache has resolved a given tier.
+func (s *Server) suitableBlockUncached(ctx context.Context, minSignaturesRequired uint64) (interfaces.ReadOnlySignedBeaconBlock, error) {
 	st, err := s.HeadFetcher.HeadState(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get head state")
@@ -0,0 +1,43 @@
+package lightclient
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	lightclient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// SaveUpdate stores update as the best known update for its sync committee period,
+// going through lightclient.IngestUpdate so BeaconDB.LightClientUpdates is only ever
+// overwritten by something that actually ranks higher. FinalityUpdate calls it with the
+// full update it derives locally; no gossip or req-resp path calls it. On success it
+// announces the period boundary on the light_client_update SSE topic. The returned bool
+// reports whether update was actually stored.
+func (s *Server) SaveUpdate(ctx context.Context, update interfaces.LightClientUpdate) (bool, error) {
+	stored, err := lightclient.IngestUpdate(ctx, s.BeaconDB, update)
+	if err != nil || !stored {
+		return stored, err
+	}
+
+	data, err := structs.LightClientUpdateFromConsensus(update)
+	if err != nil {
+		return true, errors.Wrap(err, "could not convert light client update")
+	}
+	resp := &structs.LightClientUpdateResponse{
+		Version: version.String(update.Version()),
+		Data:    data,
+	}
+	attestedRoot, err := update.AttestedHeader().Beacon().HashTreeRoot()
+	if err != nil {
+		return true, errors.Wrap(err, "could not hash attested header")
+	}
+
+	period := lightclient.SyncCommitteePeriodAtSlot(uint64(update.AttestedHeader().Beacon().Slot))
+	if err := s.PublishUpdate(period, resp, attestedRoot); err != nil {
+		return true, errors.Wrap(err, "could not publish light client update")
+	}
+	return true, nil
+}
@@ -0,0 +1,53 @@
+package lightclient
+
+import (
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/v5/async/event"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
+)
+
+// Server defines a server implementation of the gRPC-gateway light client API.
+type Server struct {
+	Blocker          lookup.Blocker
+	Stater           lookup.Stater
+	HeadFetcher      blockchain.HeadFetcher
+	ChainInfoFetcher blockchain.ChainInfoFetcher
+	// BeaconDB needs write access (not just db.ReadOnlyDatabase) so SaveUpdate can persist
+	// the best known update per sync committee period via lightclient.IngestUpdate.
+	BeaconDB db.Database
+
+	// LightClientFeed notifies subscribers of newly produced finality, optimistic and
+	// period-boundary updates (as *Event values). It is the same feed instance the shared
+	// events broker (beacon-chain/rpc/eth/events.Server.LightClient) subscribes to in order
+	// to forward light_client_finality_update, light_client_optimistic_update and
+	// light_client_update onto the real /eth/v1/events SSE stream - this package never reads
+	// its own feed, it only publishes. Whoever constructs the beacon node must point both
+	// Servers at the same *event.Feed for those topics to reach HTTP clients.
+	LightClientFeed *event.Feed
+
+	// StateNotifier drives suitableBlockCache: if set, initSuitableBlockCache starts a
+	// background subscriber that keeps the cache current off new block events instead of
+	// suitableBlock walking back from head on every request. May be left nil (e.g. in
+	// tests), in which case suitableBlock always falls back to the uncached walk.
+	StateNotifier statefeed.Notifier
+
+	cacheInit             sync.Once
+	finalityUpdateCache   *lightClientUpdateCache
+	optimisticUpdateCache *lightClientUpdateCache
+
+	suitableBlockCacheInit sync.Once
+	sbCache                *suitableBlockCache
+}
+
+// initCaches lazily creates the ring buffers on first use so callers that construct a
+// Server via a plain struct literal (as most tests do) don't need to know about them.
+func (s *Server) initCaches() {
+	s.cacheInit.Do(func() {
+		s.finalityUpdateCache = newLightClientUpdateCache()
+		s.optimisticUpdateCache = newLightClientUpdateCache()
+	})
+}
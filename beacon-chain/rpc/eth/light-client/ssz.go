@@ -0,0 +1,83 @@
+package lightclient
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// sszMarshaler is satisfied by every light client update/bootstrap consensus type.
+type sszMarshaler interface {
+	MarshalSSZ() ([]byte, error)
+	Version() int
+}
+
+// forkVersionForUpdateVersion maps a light client update's fork version constant
+// (runtime/version.Altair and newer) to its raw fork version bytes, needed to compute
+// the fork digest that prefixes each element of an SSZ updates-by-range response.
+func forkVersionForUpdateVersion(v int) ([]byte, error) {
+	cfg := params.BeaconConfig()
+	switch v {
+	case version.Altair:
+		return cfg.AltairForkVersion, nil
+	case version.Bellatrix:
+		return cfg.BellatrixForkVersion, nil
+	case version.Capella:
+		return cfg.CapellaForkVersion, nil
+	case version.Deneb:
+		return cfg.DenebForkVersion, nil
+	case version.Electra:
+		return cfg.ElectraForkVersion, nil
+	default:
+		return nil, errors.Errorf("unsupported light client update version %s", version.String(v))
+	}
+}
+
+// EncodeUpdateWithForkDigest frames a single update as (fork_digest || ssz_length(8) ||
+// update_ssz) per the beacon-API updates-by-range SSZ encoding. It is exported so other
+// servers of the same update stream, such as the Portal Network bridge, don't need their
+// own copy of the fork-digest bookkeeping.
+func (s *Server) EncodeUpdateWithForkDigest(update sszMarshaler) ([]byte, error) {
+	genesisValidatorsRoot := s.ChainInfoFetcher.GenesisValidatorsRoot()
+
+	forkVersion, err := forkVersionForUpdateVersion(update.Version())
+	if err != nil {
+		return nil, err
+	}
+	digest, err := signing.ComputeForkDigest(forkVersion, genesisValidatorsRoot[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute fork digest")
+	}
+	sszBytes, err := update.MarshalSSZ()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal update to SSZ")
+	}
+
+	lengthPrefix := make([]byte, 8)
+	binary.LittleEndian.PutUint64(lengthPrefix, uint64(len(sszBytes)))
+
+	out := make([]byte, 0, len(digest)+len(lengthPrefix)+len(sszBytes))
+	out = append(out, digest[:]...)
+	out = append(out, lengthPrefix...)
+	out = append(out, sszBytes...)
+	return out, nil
+}
+
+// marshalUpdatesByRangeSSZ encodes a contiguous slice of updates using the beacon-API
+// framing for updates-by-range: each element is (fork_digest || ssz_length(8) || update_ssz)
+// concatenated back-to-back, so a consumer can decode the stream without out-of-band
+// fork version hints.
+func (s *Server) marshalUpdatesByRangeSSZ(updates []sszMarshaler) ([]byte, error) {
+	var buf []byte
+	for _, update := range updates {
+		encoded, err := s.EncodeUpdateWithForkDigest(update)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, encoded...)
+	}
+	return buf, nil
+}
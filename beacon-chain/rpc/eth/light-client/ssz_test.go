@@ -0,0 +1,120 @@
+package lightclient
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// sszTestChainInfoFetcher is the minimal blockchain.ChainInfoFetcher implementation
+// EncodeUpdateWithForkDigest needs: only GenesisValidatorsRoot is ever read.
+type sszTestChainInfoFetcher struct {
+	blockchain.ChainInfoFetcher
+	genesisValidatorsRoot [32]byte
+}
+
+func (f *sszTestChainInfoFetcher) GenesisValidatorsRoot() [32]byte {
+	return f.genesisValidatorsRoot
+}
+
+// sszTestUpdate is the minimal sszMarshaler a test needs: a fixed version and payload.
+type sszTestUpdate struct {
+	version int
+	ssz     []byte
+}
+
+func (u *sszTestUpdate) Version() int { return u.version }
+
+func (u *sszTestUpdate) MarshalSSZ() ([]byte, error) { return u.ssz, nil }
+
+func TestForkVersionForUpdateVersion(t *testing.T) {
+	cfg := params.BeaconConfig()
+	tests := []struct {
+		version int
+		want    []byte
+	}{
+		{version.Altair, cfg.AltairForkVersion},
+		{version.Bellatrix, cfg.BellatrixForkVersion},
+		{version.Capella, cfg.CapellaForkVersion},
+		{version.Deneb, cfg.DenebForkVersion},
+		{version.Electra, cfg.ElectraForkVersion},
+	}
+	for _, tt := range tests {
+		got, err := forkVersionForUpdateVersion(tt.version)
+		if err != nil {
+			t.Fatalf("forkVersionForUpdateVersion(%d) returned an error: %v", tt.version, err)
+		}
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf("forkVersionForUpdateVersion(%d) = %x, want %x", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestForkVersionForUpdateVersion_RejectsUnknownVersion(t *testing.T) {
+	if _, err := forkVersionForUpdateVersion(-1); err == nil {
+		t.Fatal("expected an error for an unsupported update version")
+	}
+}
+
+func TestEncodeUpdateWithForkDigest_Framing(t *testing.T) {
+	s := &Server{ChainInfoFetcher: &sszTestChainInfoFetcher{genesisValidatorsRoot: [32]byte{0x01, 0x02}}}
+	update := &sszTestUpdate{version: version.Altair, ssz: []byte("payload")}
+
+	encoded, err := s.EncodeUpdateWithForkDigest(update)
+	if err != nil {
+		t.Fatalf("EncodeUpdateWithForkDigest returned an error: %v", err)
+	}
+
+	forkVersion, err := forkVersionForUpdateVersion(update.version)
+	if err != nil {
+		t.Fatalf("forkVersionForUpdateVersion returned an error: %v", err)
+	}
+	genesisValidatorsRoot := [32]byte{0x01, 0x02}
+	wantDigest, err := signing.ComputeForkDigest(forkVersion, genesisValidatorsRoot[:])
+	if err != nil {
+		t.Fatalf("ComputeForkDigest returned an error: %v", err)
+	}
+
+	if len(encoded) != len(wantDigest)+8+len(update.ssz) {
+		t.Fatalf("encoded length = %d, want %d", len(encoded), len(wantDigest)+8+len(update.ssz))
+	}
+	if !bytes.Equal(encoded[:4], wantDigest[:]) {
+		t.Errorf("fork digest = %x, want %x", encoded[:4], wantDigest)
+	}
+	gotLength := binary.LittleEndian.Uint64(encoded[4:12])
+	if gotLength != uint64(len(update.ssz)) {
+		t.Errorf("length prefix = %d, want %d", gotLength, len(update.ssz))
+	}
+	if !bytes.Equal(encoded[12:], update.ssz) {
+		t.Errorf("ssz payload = %x, want %x", encoded[12:], update.ssz)
+	}
+}
+
+func TestMarshalUpdatesByRangeSSZ_ConcatenatesFramedUpdates(t *testing.T) {
+	s := &Server{ChainInfoFetcher: &sszTestChainInfoFetcher{genesisValidatorsRoot: [32]byte{0xaa}}}
+	first := &sszTestUpdate{version: version.Altair, ssz: []byte("one")}
+	second := &sszTestUpdate{version: version.Bellatrix, ssz: []byte("two")}
+
+	got, err := s.marshalUpdatesByRangeSSZ([]sszMarshaler{first, second})
+	if err != nil {
+		t.Fatalf("marshalUpdatesByRangeSSZ returned an error: %v", err)
+	}
+
+	firstEncoded, err := s.EncodeUpdateWithForkDigest(first)
+	if err != nil {
+		t.Fatalf("EncodeUpdateWithForkDigest returned an error: %v", err)
+	}
+	secondEncoded, err := s.EncodeUpdateWithForkDigest(second)
+	if err != nil {
+		t.Fatalf("EncodeUpdateWithForkDigest returned an error: %v", err)
+	}
+	want := append(append([]byte{}, firstEncoded...), secondEncoded...)
+	if !bytes.Equal(got, want) {
+		t.Errorf("marshalUpdatesByRangeSSZ() = %x, want %x", got, want)
+	}
+}
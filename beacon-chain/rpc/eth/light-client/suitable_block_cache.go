@@ -0,0 +1,229 @@
+package lightclient
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+const (
+	tierMinParticipants           = "min_participants"
+	tierSupermajorityParticipants = "supermajority_participants"
+)
+
+var (
+	suitableBlockCacheCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "light_client_suitable_block_cache_total",
+		Help: "Count of suitableBlock lookups, labeled by bit-count tier and whether they were served from cache.",
+	}, []string{"tier", "outcome"})
+	suitableBlockLagSlots = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "light_client_suitable_block_lag_slots",
+		Help: "Slots between the current head and the cached suitable block, by bit-count tier.",
+	}, []string{"tier"})
+)
+
+// cachedSuitableBlock pairs a block known to satisfy a bit-count tier with the root it was
+// reached at, so a reorg can tell how far back up the new chain it needs to walk before
+// hitting a block it already knows satisfies the tier.
+type cachedSuitableBlock struct {
+	block        interfaces.ReadOnlySignedBeaconBlock
+	root         [32]byte
+	participants uint64
+}
+
+// suitableBlockCache tracks, per bit-count tier, the latest block on the canonical chain
+// known to clear that tier's threshold. It is kept current by onNewHeadBlock instead of
+// being recomputed by walking back from head on every beacon-API request.
+type suitableBlockCache struct {
+	mu                        sync.RWMutex
+	headRoot                  [32]byte
+	headSlot                  primitives.Slot
+	minParticipants           *cachedSuitableBlock
+	supermajorityParticipants *cachedSuitableBlock
+}
+
+func newSuitableBlockCache() *suitableBlockCache {
+	return &suitableBlockCache{}
+}
+
+// suitableBlockCacheTierForMinSignatures maps a caller's minSignaturesRequired (FinalityUpdate
+// passes the lower ceil(MinSyncCommitteeParticipants*2/3) bar, OptimisticUpdate the full
+// MinSyncCommitteeParticipants bar) onto the tier that actually caches blocks clearing that
+// bar. Anything below the full requirement only needs the supermajority tier's weaker
+// guarantee; only a caller demanding the full count needs the stricter one.
+func suitableBlockCacheTierForMinSignatures(minSignaturesRequired uint64) string {
+	if minSignaturesRequired < params.BeaconConfig().MinSyncCommitteeParticipants {
+		return tierSupermajorityParticipants
+	}
+	return tierMinParticipants
+}
+
+// get returns the cached block for tier and the most recently seen head slot (for lag
+// reporting), or a nil block if the cache hasn't resolved that tier yet.
+func (c *suitableBlockCache) get(tier string) (*cachedSuitableBlock, primitives.Slot) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if tier == tierSupermajorityParticipants {
+		return c.supermajorityParticipants, c.headSlot
+	}
+	return c.minParticipants, c.headSlot
+}
+
+// initSuitableBlockCache lazily creates the cache and, if StateNotifier is set, starts the
+// background subscriber that keeps it current. Safe to call repeatedly and from multiple
+// goroutines.
+func (s *Server) initSuitableBlockCache() {
+	s.suitableBlockCacheInit.Do(func() {
+		s.sbCache = newSuitableBlockCache()
+		if s.StateNotifier != nil {
+			go s.subscribeSuitableBlockCache()
+		}
+	})
+}
+
+// subscribeSuitableBlockCache listens for newly processed canonical blocks on the state
+// feed and folds each one into suitableBlockCache.
+func (s *Server) subscribeSuitableBlockCache() {
+	events := make(chan *statefeed.Event, 1)
+	sub := s.StateNotifier.StateFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case evt := <-events:
+			if evt.Type != statefeed.BlockProcessed {
+				continue
+			}
+			data, ok := evt.Data.(*statefeed.BlockProcessedData)
+			if !ok || !data.Verified {
+				continue
+			}
+			if err := s.onNewHeadBlock(context.Background(), data.SignedBlock, data.BlockRoot); err != nil {
+				log.WithError(err).Error("Could not update light client suitable block cache")
+			}
+		case err := <-sub.Err():
+			log.WithError(err).Error("Light client suitable block cache subscription failed")
+			return
+		}
+	}
+}
+
+// onNewHeadBlock folds a newly processed block into suitableBlockCache. When it simply
+// extends the previously seen head, each tier is updated in O(1) from the new block's
+// SyncAggregate alone. On a reorg - the new block's parent isn't the previously seen head -
+// each tier is recomputed by walking back from the new block only until a block already
+// known to satisfy that tier is reached, since everything before the fork point is
+// unchanged.
+func (s *Server) onNewHeadBlock(ctx context.Context, blk interfaces.ReadOnlySignedBeaconBlock, blockRoot [32]byte) error {
+	s.initSuitableBlockCache()
+
+	minRequired := params.BeaconConfig().MinSyncCommitteeParticipants
+	supermajorityRequired := uint64(math.Ceil(float64(minRequired) * 2 / 3))
+
+	s.sbCache.mu.Lock()
+	defer s.sbCache.mu.Unlock()
+
+	var zeroRoot [32]byte
+	parentRoot := blk.Block().ParentRoot()
+	reorg := s.sbCache.headRoot != zeroRoot && parentRoot != s.sbCache.headRoot
+
+	s.sbCache.headRoot = blockRoot
+	s.sbCache.headSlot = blk.Block().Slot()
+
+	if reorg {
+		return s.recomputeSuitableBlockCacheLocked(ctx, blk, blockRoot, minRequired, supermajorityRequired)
+	}
+
+	var participants uint64
+	if agg, err := blk.Block().Body().SyncAggregate(); err == nil {
+		participants = agg.SyncCommitteeBits.Count()
+	}
+	if participants >= minRequired {
+		s.sbCache.minParticipants = &cachedSuitableBlock{block: blk, root: blockRoot, participants: participants}
+	}
+	if participants >= supermajorityRequired {
+		s.sbCache.supermajorityParticipants = &cachedSuitableBlock{block: blk, root: blockRoot, participants: participants}
+	}
+	return nil
+}
+
+// recomputeSuitableBlockCacheLocked rebuilds both tiers after a reorg. s.sbCache.mu must
+// already be held for writing.
+func (s *Server) recomputeSuitableBlockCacheLocked(ctx context.Context, blk interfaces.ReadOnlySignedBeaconBlock, blockRoot [32]byte, minRequired, supermajorityRequired uint64) error {
+	var newMin, newSupermajority *cachedSuitableBlock
+
+	cur, curRoot := blk, blockRoot
+	var minDone, supermajorityDone bool
+	for {
+		var participants uint64
+		if agg, err := cur.Block().Body().SyncAggregate(); err == nil {
+			participants = agg.SyncCommitteeBits.Count()
+		}
+		if newMin == nil && participants >= minRequired {
+			newMin = &cachedSuitableBlock{block: cur, root: curRoot, participants: participants}
+		}
+		if newSupermajority == nil && participants >= supermajorityRequired {
+			newSupermajority = &cachedSuitableBlock{block: cur, root: curRoot, participants: participants}
+		}
+
+		// Each tier stops independently: finding a new qualifying block for that tier, or
+		// reaching that tier's own previously cached root (which may no longer be canonical
+		// for the other tier), satisfies it. A reorg where the old min-participants ancestor
+		// stays canonical but the old supermajority ancestor doesn't must keep walking for the
+		// supermajority tier past that point, rather than stopping early and leaving a stale
+		// or orphaned block cached for it.
+		minDone = suitableBlockCacheTierDone(newMin != nil, s.sbCache.minParticipants, curRoot)
+		supermajorityDone = suitableBlockCacheTierDone(newSupermajority != nil, s.sbCache.supermajorityParticipants, curRoot)
+		if minDone && supermajorityDone {
+			break
+		}
+
+		parentRoot := cur.Block().ParentRoot()
+		parent, err := s.Blocker.Block(ctx, parentRoot[:])
+		if err != nil {
+			return errors.Wrap(err, "could not get parent block while recomputing suitable block cache")
+		}
+		if parent == nil {
+			break
+		}
+		cur, curRoot = parent, parentRoot
+	}
+
+	if newMin != nil {
+		s.sbCache.minParticipants = newMin
+	} else if suitableBlockCacheTierShouldClear(newMin, minDone) {
+		s.sbCache.minParticipants = nil
+	}
+	if newSupermajority != nil {
+		s.sbCache.supermajorityParticipants = newSupermajority
+	} else if suitableBlockCacheTierShouldClear(newSupermajority, supermajorityDone) {
+		s.sbCache.supermajorityParticipants = nil
+	}
+	return nil
+}
+
+// suitableBlockCacheTierDone reports whether a single tier's reorg walk can stop at the
+// current block: either this step found a new qualifying block for the tier (found), or
+// the walk reached that tier's own previously cached root. Kept separate per tier - rather
+// than a single flag shared across both - so a reorg where only one tier's old ancestor is
+// still canonical doesn't cut the other tier's walk short.
+func suitableBlockCacheTierDone(found bool, oldCached *cachedSuitableBlock, curRoot [32]byte) bool {
+	return found || (oldCached != nil && curRoot == oldCached.root)
+}
+
+// suitableBlockCacheTierShouldClear reports whether a tier's reorg walk ran out of
+// ancestry (hit a missing parent) without resolving: resolved is the tier's freshly found
+// qualifying block, if any, and done is the tier's last suitableBlockCacheTierDone result.
+// A tier with neither had its old cached block live on the fork the reorg just abandoned,
+// since the walk never confirmed that block is still reachable from the new head.
+func suitableBlockCacheTierShouldClear(resolved *cachedSuitableBlock, done bool) bool {
+	return resolved == nil && !done
+}
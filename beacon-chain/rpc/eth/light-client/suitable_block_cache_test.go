@@ -0,0 +1,98 @@
+package lightclient
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+)
+
+func TestSuitableBlockCacheTierDone(t *testing.T) {
+	rootA := [32]byte{0xaa}
+	rootB := [32]byte{0xbb}
+	cachedAtA := &cachedSuitableBlock{root: rootA}
+
+	tests := []struct {
+		name      string
+		found     bool
+		oldCached *cachedSuitableBlock
+		curRoot   [32]byte
+		want      bool
+	}{
+		{"found a new qualifying block", true, nil, rootB, true},
+		{"no old cache and nothing found yet keeps walking", false, nil, rootB, false},
+		{"reached the tier's own cached root", false, cachedAtA, rootA, true},
+		{"has not yet reached the tier's own cached root", false, cachedAtA, rootB, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suitableBlockCacheTierDone(tt.found, tt.oldCached, tt.curRoot); got != tt.want {
+				t.Errorf("suitableBlockCacheTierDone() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSuitableBlockCacheTierDone_IndependentTiers reproduces the reorg shape the review
+// flagged: the min-participants tier's old ancestor is still canonical, but the
+// supermajority tier's old ancestor is not (or sits further back). The min tier must be
+// able to stop at its own old root while the supermajority tier keeps walking past it.
+func TestSuitableBlockCacheTierDone_IndependentTiers(t *testing.T) {
+	minOldRoot := [32]byte{0x01}
+	supermajorityOldRoot := [32]byte{0x02} // no longer canonical after the reorg
+	minCached := &cachedSuitableBlock{root: minOldRoot}
+	supermajorityCached := &cachedSuitableBlock{root: supermajorityOldRoot}
+
+	// Walking the new canonical chain reaches the min tier's old root first.
+	curRoot := minOldRoot
+
+	minDone := suitableBlockCacheTierDone(false, minCached, curRoot)
+	supermajorityDone := suitableBlockCacheTierDone(false, supermajorityCached, curRoot)
+
+	if !minDone {
+		t.Error("expected the min-participants tier to be done once its own old root is reached")
+	}
+	if supermajorityDone {
+		t.Error("expected the supermajority tier to keep walking past the min tier's old root, since its own old root was not reached")
+	}
+}
+
+// TestSuitableBlockCacheTierForMinSignatures reproduces the review finding that
+// FinalityUpdate's ceil(MinSyncCommitteeParticipants*2/3) bar and OptimisticUpdate's full
+// MinSyncCommitteeParticipants bar must resolve to different tiers.
+func TestSuitableBlockCacheTierForMinSignatures(t *testing.T) {
+	minRequired := params.BeaconConfig().MinSyncCommitteeParticipants
+	supermajorityRequired := uint64(math.Ceil(float64(minRequired) * 2 / 3))
+
+	if got := suitableBlockCacheTierForMinSignatures(supermajorityRequired); got != tierSupermajorityParticipants {
+		t.Errorf("FinalityUpdate's bar resolved to tier %q, want %q", got, tierSupermajorityParticipants)
+	}
+	if got := suitableBlockCacheTierForMinSignatures(minRequired); got != tierMinParticipants {
+		t.Errorf("OptimisticUpdate's bar resolved to tier %q, want %q", got, tierMinParticipants)
+	}
+}
+
+// TestSuitableBlockCacheTierShouldClear reproduces the review finding that a reorg walk
+// which runs out of ancestry before resolving a tier left that tier's stale, possibly
+// orphaned block in place. It must be cleared instead.
+func TestSuitableBlockCacheTierShouldClear(t *testing.T) {
+	resolved := &cachedSuitableBlock{root: [32]byte{0xaa}}
+
+	tests := []struct {
+		name     string
+		resolved *cachedSuitableBlock
+		done     bool
+		want     bool
+	}{
+		{"found a new qualifying block", resolved, false, false},
+		{"reached the tier's own cached root", nil, true, false},
+		{"ran out of ancestry unresolved", nil, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suitableBlockCacheTierShouldClear(tt.resolved, tt.done); got != tt.want {
+				t.Errorf("suitableBlockCacheTierShouldClear() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package lightclient
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	lightclient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+)
+
+// verifyOutgoingFinalityUpdate self-checks a freshly built finality update against
+// lightclient.VerifyLightClientFinalityUpdate before it's handed to a caller, gathering the
+// attested sync committee out of attestedState and the fork version the signature was
+// actually made under out of signature_slot - not the attested header's own fork, since a
+// fork boundary can fall between attested_slot and signature_slot.
+func (s *Server) verifyOutgoingFinalityUpdate(update interfaces.LightClientFinalityUpdate, attestedState state.BeaconState) error {
+	committee, err := attestedState.CurrentSyncCommittee()
+	if err != nil {
+		return errors.Wrap(err, "could not get current sync committee")
+	}
+	forkVersion := lightclient.ForkVersionForSlot(update.SignatureSlot())
+	genesisValidatorsRoot := s.ChainInfoFetcher.GenesisValidatorsRoot()
+	return lightclient.VerifyLightClientFinalityUpdate(s.ChainInfoFetcher.CurrentSlot(), update, committee, forkVersion, genesisValidatorsRoot[:])
+}
+
+// verifyOutgoingUpdate self-checks a freshly built full periodic LightClientUpdate against
+// lightclient.VerifyLightClientUpdate before it's handed to SaveUpdate, the same way
+// verifyOutgoingFinalityUpdate checks a finality update before it's served. This is the
+// update type light clients trust for an entire sync committee period, so unlike the
+// finality/optimistic checks it also verifies the next-sync-committee Merkle proof.
+func (s *Server) verifyOutgoingUpdate(update interfaces.LightClientUpdate, attestedState state.BeaconState) error {
+	committee, err := attestedState.CurrentSyncCommittee()
+	if err != nil {
+		return errors.Wrap(err, "could not get current sync committee")
+	}
+	forkVersion := lightclient.ForkVersionForSlot(update.SignatureSlot())
+	genesisValidatorsRoot := s.ChainInfoFetcher.GenesisValidatorsRoot()
+	return lightclient.VerifyLightClientUpdate(s.ChainInfoFetcher.CurrentSlot(), update, committee, forkVersion, genesisValidatorsRoot[:])
+}
+
+// verifyOutgoingOptimisticUpdate is the optimistic-update counterpart of
+// verifyOutgoingFinalityUpdate.
+func (s *Server) verifyOutgoingOptimisticUpdate(update interfaces.LightClientOptimisticUpdate, attestedState state.BeaconState) error {
+	committee, err := attestedState.CurrentSyncCommittee()
+	if err != nil {
+		return errors.Wrap(err, "could not get current sync committee")
+	}
+	forkVersion := lightclient.ForkVersionForSlot(update.SignatureSlot())
+	genesisValidatorsRoot := s.ChainInfoFetcher.GenesisValidatorsRoot()
+	return lightclient.VerifyLightClientOptimisticUpdate(s.ChainInfoFetcher.CurrentSlot(), update, committee, forkVersion, genesisValidatorsRoot[:])
+}
+
+// VerifyFinalityUpdate self-checks a finality update this node did not itself produce -
+// one received over gossip or req-resp - the same way verifyOutgoingFinalityUpdate checks
+// one this node is about to serve. It is exported so the p2p gossip validator can refuse a
+// fabricated update before it's accepted into the mesh, rather than only self-checking what
+// this node publishes.
+func (s *Server) VerifyFinalityUpdate(ctx context.Context, update interfaces.LightClientFinalityUpdate) error {
+	attestedState, err := s.Stater.StateBySlot(ctx, update.AttestedHeader().Beacon().Slot)
+	if err != nil {
+		return errors.Wrap(err, "could not get attested state")
+	}
+	return s.verifyOutgoingFinalityUpdate(update, attestedState)
+}
+
+// VerifyOptimisticUpdate is the optimistic-update counterpart of VerifyFinalityUpdate.
+func (s *Server) VerifyOptimisticUpdate(ctx context.Context, update interfaces.LightClientOptimisticUpdate) error {
+	attestedState, err := s.Stater.StateBySlot(ctx, update.AttestedHeader().Beacon().Slot)
+	if err != nil {
+		return errors.Wrap(err, "could not get attested state")
+	}
+	return s.verifyOutgoingOptimisticUpdate(update, attestedState)
+}